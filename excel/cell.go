@@ -0,0 +1,266 @@
+package excel
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// 单元格类型：布尔
+	_B = "b"
+	// 单元格类型：错误，如#DIV/0!
+	_E = "e"
+	// 单元格类型：公式计算出的字符串
+	_Str = "str"
+	// 单元格类型：ISO 8601日期
+	_D = "d"
+
+	// 共享公式
+	_SharedFormula = "shared"
+)
+
+// CellType is the type of the value of a cell.
+type CellType int
+
+const (
+	// CellTypeNumber is a number, it's the default type.
+	CellTypeNumber CellType = iota
+	// CellTypeString is a shared string, inline string or string calculated by formula.
+	CellTypeString
+	// CellTypeBool is a boolean, the value is "1" or "0".
+	CellTypeBool
+	// CellTypeDate is a number formatted as date or a ISO 8601 date, the value is RFC3339 text.
+	CellTypeDate
+	// CellTypeError is an error such as "#DIV/0!" or "#N/A".
+	CellTypeError
+)
+
+func (t CellType) String() string {
+	switch t {
+	case CellTypeNumber:
+		return "number"
+	case CellTypeString:
+		return "string"
+	case CellTypeBool:
+		return "bool"
+	case CellTypeDate:
+		return "date"
+	case CellTypeError:
+		return "error"
+	default:
+		return "CellType(" + strconv.Itoa(int(t)) + ")"
+	}
+}
+
+// Cell is the metadata of a cell.
+// A field of Cell (or *Cell) will be filled with the metadata instead of the text,
+// and so does the element of map or slice.
+type Cell struct {
+	// The reference of cell, such as "A1".
+	Ref string
+	// The type of value.
+	Type CellType
+	// The text of value, it's the cached result if the cell is a formula.
+	Value string
+	// The formula without the leading "=", empty if the cell is a literal.
+	// The formula shared from another cell is translated to this cell.
+	Formula string
+}
+
+// IsFormula report whether the cell is calculated by formula.
+func (c *Cell) IsFormula() bool {
+	return c.Formula != ""
+}
+
+// valueError return a *CellValueError if the cell is an error.
+func (c *Cell) valueError() error {
+	if c.Type != CellTypeError {
+		return nil
+	}
+	return &CellValueError{Ref: c.Ref, Value: c.Value, Formula: c.Formula}
+}
+
+// CellValueError means the value of cell is an error such as "#DIV/0!",
+// it's returned when reading the cell into anything except Cell.
+type CellValueError struct {
+	// The reference of cell, such as "A1".
+	Ref string
+	// The error value, such as "#DIV/0!".
+	Value string
+	// The formula of cell, may be empty.
+	Formula string
+}
+
+func (e *CellValueError) Error() string {
+	if e.Formula != "" {
+		return fmt.Sprintf("cell %s is error value %s of formula =%s", e.Ref, e.Value, e.Formula)
+	}
+	return fmt.Sprintf("cell %s is error value %s", e.Ref, e.Value)
+}
+
+var cellType = reflect.TypeOf(Cell{})
+
+// setCell set cell into v and return true if v is Cell or *Cell.
+func setCell(cell *Cell, v reflect.Value) bool {
+	switch {
+	case v.Type() == cellType:
+		v.Set(reflect.ValueOf(*cell))
+		return true
+	case v.Kind() == reflect.Ptr && v.Type().Elem() == cellType:
+		c := *cell
+		v.Set(reflect.ValueOf(&c))
+		return true
+	}
+	return false
+}
+
+// sharedFormula is the master of a shared formula.
+type sharedFormula struct {
+	formula string
+	row     int
+	column  int
+}
+
+// shiftFormula move the relative references in formula by rows and columns,
+// the reference moved out of sheet will be "#REF!".
+func shiftFormula(formula string, rows, columns int) string {
+	if rows == 0 && columns == 0 {
+		return formula
+	}
+	var b strings.Builder
+	for i := 0; i < len(formula); {
+		c := formula[i]
+		switch {
+		case c == '"' || c == '\'':
+			// string literal or quoted sheet name, the quote is escaped by doubling.
+			j := i + 1
+			for ; j < len(formula); j++ {
+				if formula[j] == c {
+					if j+1 < len(formula) && formula[j+1] == c {
+						j++
+						continue
+					}
+					break
+				}
+			}
+			if j >= len(formula) {
+				j = len(formula) - 1
+			}
+			b.WriteString(formula[i : j+1])
+			i = j + 1
+		case c == '$' || isLetter(c):
+			if i > 0 && isIdentChar(formula[i-1]) {
+				b.WriteByte(c)
+				i++
+				break
+			}
+			if n, ref := shiftReference(formula[i:], rows, columns); n > 0 {
+				b.WriteString(ref)
+				i += n
+				break
+			}
+			// copy the whole identifier, such as function name.
+			j := i + 1
+			for j < len(formula) && isIdentChar(formula[j]) {
+				j++
+			}
+			b.WriteString(formula[i:j])
+			i = j
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+// shiftReference parse a A1 reference at the start of s,
+// return the length of it and the moved reference, n is 0 if not a reference.
+func shiftReference(s string, rows, columns int) (n int, ref string) {
+	i := 0
+	absColumn := i < len(s) && s[i] == '$'
+	if absColumn {
+		i++
+	}
+	start := i
+	for i < len(s) && isLetter(s[i]) && i-start < 3 {
+		i++
+	}
+	column := strings.ToUpper(s[start:i])
+	if column == "" {
+		return 0, ""
+	}
+	absRow := i < len(s) && s[i] == '$'
+	if absRow {
+		i++
+	}
+	start = i
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	if start == i || (i < len(s) && (isIdentChar(s[i]) || s[i] == '(' || s[i] == '!')) {
+		return 0, ""
+	}
+	row, err := strconv.Atoi(s[start:i])
+	if err != nil {
+		return 0, ""
+	}
+	columnIndex := ToDecimalism(column)
+	if !absColumn {
+		columnIndex += columns
+	}
+	if !absRow {
+		row += rows
+	}
+	if columnIndex < 0 || columnIndex >= _MaxColumns || row < 1 || row > _MaxRows {
+		return i, "#REF!"
+	}
+	var b strings.Builder
+	if absColumn {
+		b.WriteByte('$')
+	}
+	b.WriteString(ToColumnName(columnIndex))
+	if absRow {
+		b.WriteByte('$')
+	}
+	b.WriteString(strconv.Itoa(row))
+	return i, b.String()
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z')
+}
+
+func isIdentChar(c byte) bool {
+	return isLetter(c) || (c >= '0' && c <= '9') || c == '_' || c == '.' || c == '$'
+}
+
+// restValue convert cell into the value of type t, t is the element type of rest map.
+// The cell is typed by its type for interface{}, such as float64 for number and time.Time for date.
+func restValue(cell *Cell, t reflect.Type) reflect.Value {
+	switch {
+	case t == cellType:
+		return reflect.ValueOf(*cell)
+	case t.Kind() == reflect.String:
+		return reflect.ValueOf(cell.Value).Convert(t)
+	}
+	var value interface{} = cell.Value
+	switch cell.Type {
+	case CellTypeNumber:
+		if f, err := strconv.ParseFloat(cell.Value, 64); err == nil {
+			value = f
+		}
+	case CellTypeBool:
+		if b, err := strconv.ParseBool(cell.Value); err == nil {
+			value = b
+		}
+	case CellTypeDate:
+		if tm, err := time.Parse(time.RFC3339Nano, cell.Value); err == nil {
+			value = tm
+		}
+	}
+	return reflect.ValueOf(&value).Elem()
+}
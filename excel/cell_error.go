@@ -0,0 +1,65 @@
+package excel
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CellError is the error of reading a cell into a field, it tells exactly where the bad cell is.
+type CellError struct {
+	// The name of sheet.
+	Sheet string
+	// The number of row, starts from 1 like excel.
+	Row int
+	// The letter of column, such as "C", empty if the error is of the whole row, see RowValidator.
+	Column string
+	// The title of column.
+	Title string
+	// The name of target field, empty if not read into a struct.
+	Field string
+	// The raw text of cell, it's the default value if the cell is empty.
+	Value string
+	// The cause, such as *CellValueError or error of convert.
+	Err error
+}
+
+func (e *CellError) Error() string {
+	var b strings.Builder
+	if e.Column == "" {
+		fmt.Fprintf(&b, "sheet %s row %d", e.Sheet, e.Row)
+	} else {
+		fmt.Fprintf(&b, "sheet %s cell %s%d", e.Sheet, e.Column, e.Row)
+	}
+	if e.Title != "" {
+		fmt.Fprintf(&b, " (title %q)", e.Title)
+	}
+	if e.Field != "" {
+		fmt.Fprintf(&b, " into field %s", e.Field)
+	}
+	if e.Column == "" {
+		fmt.Fprintf(&b, ": %s", e.Err)
+		return b.String()
+	}
+	fmt.Fprintf(&b, " with value %q: %s", e.Value, e.Err)
+	return b.String()
+}
+
+func (e *CellError) Unwrap() error {
+	return e.Err
+}
+
+// CellErrors is all bad cells found in collect-all mode, see Config.CollectErrors.
+type CellErrors []*CellError
+
+func (errs CellErrors) Error() string {
+	const max = 3
+	msgs := make([]string, 0, max+1)
+	for i, err := range errs {
+		if i == max {
+			msgs = append(msgs, fmt.Sprintf("and %d more", len(errs)-max))
+			break
+		}
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%d bad cells: %s", len(errs), strings.Join(msgs, "; "))
+}
@@ -0,0 +1,156 @@
+package excel
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type FormulaCell struct {
+	Price  float64
+	Total  float64
+	Result Cell   `xlsx:"column(Total)"`
+	Ptr    *Cell  `xlsx:"column(Price)"`
+	Note   string `xlsx:"column(Note)"`
+}
+
+const formulaSheetData = `<sheetData>` +
+	`<row r="1"><c r="A1" t="inlineStr"><is><t>Price</t></is></c><c r="B1" t="inlineStr"><is><t>Total</t></is></c><c r="C1" t="inlineStr"><is><t>Note</t></is></c></row>` +
+	`<row r="2"><c r="A2"><v>2</v></c><c r="B2"><f t="shared" ref="B2:B3" si="0">A2*$A$2+SUM(A$2:A2)</f><v>6</v></c><c r="C2" t="str"><f>IF(A2&gt;1,"big","small")</f><v>big</v></c></row>` +
+	`<row r="3"><c r="A3"><v>3</v></c><c r="B3"><f t="shared" si="0"/><v>11</v></c><c r="C3" t="b"><v>1</v></c></row>` +
+	`<row r="4"><c r="A4"><v>0</v></c><c r="B4" t="e"><f>1/A4</f><v>#DIV/0!</v></c></row>` +
+	`</sheetData>`
+
+func TestReadFormulaCell(t *testing.T) {
+	conn := openTestWorkbook(t, &testWorkbook{Sheets: [][2]string{{"FormulaCell", formulaSheetData}}})
+	defer conn.Close()
+
+	rd := conn.MustReader("FormulaCell")
+	defer rd.Close()
+
+	expectList := []FormulaCell{
+		{
+			Price:  2,
+			Total:  6,
+			Result: Cell{Ref: "B2", Type: CellTypeNumber, Value: "6", Formula: "A2*$A$2+SUM(A$2:A2)"},
+			Ptr:    &Cell{Ref: "A2", Type: CellTypeNumber, Value: "2"},
+			Note:   "big",
+		},
+		{
+			Price:  3,
+			Total:  11,
+			Result: Cell{Ref: "B3", Type: CellTypeNumber, Value: "11", Formula: "A3*$A$2+SUM(A$2:A3)"},
+			Ptr:    &Cell{Ref: "A3", Type: CellTypeNumber, Value: "3"},
+			Note:   "1",
+		},
+	}
+	for i, expect := range expectList {
+		if !rd.Next() {
+			t.Fatalf("expect row %d", i)
+		}
+		var got FormulaCell
+		if err := rd.Read(&got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(expect, got) {
+			t.Errorf("unexpect formula cell at %d = \n%s", i, MustJsonPrettyString(got))
+		}
+	}
+
+	if !rd.Next() {
+		t.Fatal("expect the row of error")
+	}
+	var got FormulaCell
+	err := rd.Read(&got)
+	var valueErr *CellValueError
+	if !errors.As(err, &valueErr) {
+		t.Fatalf("expect *CellValueError but got: %+v", err)
+	}
+	if valueErr.Ref != "B4" || valueErr.Value != "#DIV/0!" || valueErr.Formula != "1/A4" {
+		t.Errorf("unexpect error: %+v", valueErr)
+	}
+}
+
+func TestReadFormulaCellMap(t *testing.T) {
+	conn := openTestWorkbook(t, &testWorkbook{Sheets: [][2]string{{"FormulaCell", formulaSheetData}}})
+	defer conn.Close()
+
+	rd := conn.MustReader("FormulaCell")
+	defer rd.Close()
+
+	var list []map[string]Cell
+	if err := rd.ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 3 {
+		t.Fatalf("unexpect list: \n%s", MustJsonPrettyString(list))
+	}
+	if c := list[1]["Note"]; c.Type != CellTypeBool || c.Value != "1" || c.IsFormula() {
+		t.Errorf("unexpect bool cell: %+v", c)
+	}
+	if c := list[2]["Total"]; c.Type != CellTypeError || c.Value != "#DIV/0!" || !c.IsFormula() {
+		t.Errorf("unexpect error cell: %+v", c)
+	}
+
+	rd = conn.MustReader("FormulaCell")
+	defer rd.Close()
+	var strList []map[string]string
+	var valueErr *CellValueError
+	if err := rd.ReadAll(&strList); !errors.As(err, &valueErr) {
+		t.Errorf("expect *CellValueError but got: %+v", err)
+	}
+}
+
+func TestReadFormulaCellSkipped(t *testing.T) {
+	conn := openTestWorkbook(t, &testWorkbook{Sheets: [][2]string{{"FormulaCell", formulaSheetData}}})
+	defer conn.Close()
+
+	// the master of shared formula is in the skipped row.
+	rd, err := conn.NewReaderByConfig(&Config{Sheet: "FormulaCell", Skip: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	if !rd.Next() {
+		t.Fatal("expect row 3")
+	}
+	var got FormulaCell
+	if err = rd.Read(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Result.Value != "11" || got.Result.Formula != "A3*$A$2+SUM(A$2:A3)" {
+		t.Errorf("unexpect formula cell: %+v", got.Result)
+	}
+
+	// the master is lost, the cached value is read without formula.
+	conn = openTestWorkbook(t, &testWorkbook{Sheets: [][2]string{{"FormulaCell", strings.Replace(formulaSheetData, ` ref="B2:B3"`, "", 1)}}})
+	defer conn.Close()
+	var list []FormulaCell
+	if err = conn.MustReader("FormulaCell").ReadAll(&list); err == nil || len(list) != 2 {
+		t.Fatalf("expect the rows before error: %v", err)
+	}
+	if list[1].Result.Value != "11" || list[1].Result.Formula != "" {
+		t.Errorf("unexpect formula cell: %+v", list[1].Result)
+	}
+}
+
+func TestShiftFormula(t *testing.T) {
+	tests := []struct {
+		formula       string
+		rows, columns int
+		want          string
+	}{
+		{"A1+B2", 1, 1, "B2+C3"},
+		{"$A$1+A$1+$A1", 2, 2, "$A$1+C$1+$A3"},
+		{"SUM(A1:A10)*LOG10(B1)", 1, 0, "SUM(A2:A11)*LOG10(B2)"},
+		{`"A1"&'Sheet A1'!A1&Sheet2!B2`, 1, 0, `"A1"&'Sheet A1'!A2&Sheet2!B3`},
+		{"A1", -1, 0, "#REF!"},
+		{"TRUE()+A1", 0, 1, "TRUE()+B1"},
+	}
+	for _, tt := range tests {
+		if got := shiftFormula(tt.formula, tt.rows, tt.columns); got != tt.want {
+			t.Errorf("shiftFormula(%q, %d, %d) = %q, want %q", tt.formula, tt.rows, tt.columns, got, tt.want)
+		}
+	}
+}
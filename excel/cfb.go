@@ -0,0 +1,236 @@
+package excel
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+)
+
+// signature of Compound File Binary, the container of xls.
+var cfbSignature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+const (
+	// 扇区编号：空闲
+	_CFBFreeSect = 0xFFFFFFFF
+	// 扇区编号：链结束
+	_CFBEndOfChain = 0xFFFFFFFE
+	// 扇区编号：FAT扇区
+	_CFBFATSect = 0xFFFFFFFD
+	// 扇区编号：DIFAT扇区
+	_CFBDIFATSect = 0xFFFFFFFC
+
+	_CFBHeaderSize   = 512
+	_CFBDirEntrySize = 128
+	// 目录项类型：流
+	_CFBStream = 2
+	// 目录项类型：根
+	_CFBRoot = 5
+)
+
+// ErrInvalidCFB means the file is not a valid Compound File Binary.
+var ErrInvalidCFB = errors.New("invalid compound file binary")
+
+// cfbFile is a Compound File Binary (OLE2), only reading the streams is supported.
+type cfbFile struct {
+	r          io.ReaderAt
+	size       int64
+	sectorSize int64
+	// sector allocation table
+	fat []uint32
+	// mini sector allocation table
+	miniFAT          []uint32
+	miniSectorSize   int64
+	miniStreamCutoff uint64
+	// the mini stream is kept in the root entry
+	miniStream []byte
+	entries    []*cfbEntry
+}
+
+type cfbEntry struct {
+	name        string
+	kind        byte
+	startSector uint32
+	size        uint64
+}
+
+// isCFB report whether the header is the signature of Compound File Binary.
+func isCFB(header []byte) bool {
+	return bytes.HasPrefix(header, cfbSignature)
+}
+
+func openCFB(r io.ReaderAt, size int64) (*cfbFile, error) {
+	header := make([]byte, _CFBHeaderSize)
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCFB, err)
+	}
+	if !isCFB(header) {
+		return nil, ErrInvalidCFB
+	}
+	le := binary.LittleEndian
+	sectorShift := le.Uint16(header[30:])
+	miniSectorShift := le.Uint16(header[32:])
+	if sectorShift != 9 && sectorShift != 12 || miniSectorShift != 6 {
+		return nil, fmt.Errorf("%w: sector shift %d, mini sector shift %d", ErrInvalidCFB, sectorShift, miniSectorShift)
+	}
+	f := &cfbFile{
+		r:                r,
+		size:             size,
+		sectorSize:       1 << sectorShift,
+		miniSectorSize:   1 << miniSectorShift,
+		miniStreamCutoff: uint64(le.Uint32(header[56:])),
+	}
+	numFATSectors := le.Uint32(header[44:])
+	firstDirSector := le.Uint32(header[48:])
+	firstMiniFATSector := le.Uint32(header[60:])
+	firstDIFATSector := le.Uint32(header[68:])
+	numDIFATSectors := le.Uint32(header[72:])
+	if maxSectors := uint64(size / f.sectorSize); uint64(numFATSectors) > maxSectors || uint64(numDIFATSectors) > maxSectors {
+		return nil, fmt.Errorf("%w: %d FAT sectors and %d DIFAT sectors out of file", ErrInvalidCFB, numFATSectors, numDIFATSectors)
+	}
+
+	// the first 109 FAT sectors are in header, the others are in DIFAT sectors.
+	fatSectors := make([]uint32, 0, numFATSectors)
+	for i := 0; i < 109 && uint32(len(fatSectors)) < numFATSectors; i++ {
+		fatSectors = append(fatSectors, le.Uint32(header[76+i*4:]))
+	}
+	sector := firstDIFATSector
+	for i := uint32(0); i < numDIFATSectors && uint32(len(fatSectors)) < numFATSectors; i++ {
+		data, err := f.readSector(sector)
+		if err != nil {
+			return nil, err
+		}
+		n := len(data)/4 - 1
+		for j := 0; j < n && uint32(len(fatSectors)) < numFATSectors; j++ {
+			fatSectors = append(fatSectors, le.Uint32(data[j*4:]))
+		}
+		sector = le.Uint32(data[n*4:])
+	}
+	if uint32(len(fatSectors)) < numFATSectors {
+		return nil, fmt.Errorf("%w: expect %d FAT sectors but got %d", ErrInvalidCFB, numFATSectors, len(fatSectors))
+	}
+	for _, sector := range fatSectors {
+		data, err := f.readSector(sector)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < len(data); i += 4 {
+			f.fat = append(f.fat, le.Uint32(data[i:]))
+		}
+	}
+
+	dir, err := f.readChain(firstDirSector, f.fat, f.readSector)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i+_CFBDirEntrySize <= len(dir); i += _CFBDirEntrySize {
+		data := dir[i : i+_CFBDirEntrySize]
+		nameLen := int(le.Uint16(data[64:]))
+		if nameLen > 64 {
+			nameLen = 64
+		}
+		name := make([]uint16, 0, 32)
+		for j := 0; j+1 < nameLen; j += 2 {
+			if c := le.Uint16(data[j:]); c != 0 {
+				name = append(name, c)
+			}
+		}
+		f.entries = append(f.entries, &cfbEntry{
+			name:        string(utf16.Decode(name)),
+			kind:        data[66],
+			startSector: le.Uint32(data[116:]),
+			size:        le.Uint64(data[120:]),
+		})
+	}
+	if len(f.entries) == 0 || f.entries[0].kind != _CFBRoot {
+		return nil, fmt.Errorf("%w: root entry not exist", ErrInvalidCFB)
+	}
+	if sectorShift == 9 {
+		// the high 32 bits may be garbage in version 3.
+		for _, entry := range f.entries {
+			entry.size &= 0xFFFFFFFF
+		}
+	}
+
+	if firstMiniFATSector != _CFBEndOfChain && firstMiniFATSector != _CFBFreeSect {
+		data, err := f.readChain(firstMiniFATSector, f.fat, f.readSector)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i+4 <= len(data); i += 4 {
+			f.miniFAT = append(f.miniFAT, le.Uint32(data[i:]))
+		}
+		root := f.entries[0]
+		if f.miniStream, err = f.readChain(root.startSector, f.fat, f.readSector); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// stream read the whole stream named name, ignoring case.
+func (f *cfbFile) stream(name string) ([]byte, error) {
+	for _, entry := range f.entries {
+		if entry.kind != _CFBStream || !strings.EqualFold(entry.name, name) {
+			continue
+		}
+		var data []byte
+		var err error
+		if entry.size < f.miniStreamCutoff {
+			data, err = f.readChain(entry.startSector, f.miniFAT, f.readMiniSector)
+		} else {
+			data, err = f.readChain(entry.startSector, f.fat, f.readSector)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(data)) < entry.size {
+			return nil, fmt.Errorf("%w: stream %s is truncated", ErrInvalidCFB, name)
+		}
+		return data[:entry.size], nil
+	}
+	return nil, fmt.Errorf("%w: stream %s not exist", ErrInvalidCFB, name)
+}
+
+// readChain read the sectors chained by table from start.
+func (f *cfbFile) readChain(start uint32, table []uint32, read func(sector uint32) ([]byte, error)) ([]byte, error) {
+	var data []byte
+	for sector, n := start, 0; sector != _CFBEndOfChain; n++ {
+		if int(sector) >= len(table) || n > len(table) {
+			// out of range or a loop
+			return nil, fmt.Errorf("%w: bad sector chain", ErrInvalidCFB)
+		}
+		b, err := read(sector)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, b...)
+		sector = table[sector]
+	}
+	return data, nil
+}
+
+func (f *cfbFile) readSector(sector uint32) ([]byte, error) {
+	// the header takes the first sector, it's padded to 4096 bytes in version 4.
+	offset := int64(sector+1) * f.sectorSize
+	if sector >= _CFBDIFATSect || offset >= f.size {
+		return nil, fmt.Errorf("%w: sector %d out of range", ErrInvalidCFB, sector)
+	}
+	// the last sector may be truncated.
+	data := make([]byte, f.sectorSize)
+	if _, err := f.r.ReadAt(data, offset); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (f *cfbFile) readMiniSector(sector uint32) ([]byte, error) {
+	offset := int64(sector) * f.miniSectorSize
+	if offset+f.miniSectorSize > int64(len(f.miniStream)) {
+		return nil, fmt.Errorf("%w: mini sector %d out of range", ErrInvalidCFB, sector)
+	}
+	return f.miniStream[offset : offset+f.miniSectorSize], nil
+}
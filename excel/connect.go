@@ -3,13 +3,16 @@ package excel
 import (
 	"archive/zip"
 	"bytes"
-	"encoding/xml"
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"net/url"
+	"os"
 	"reflect"
+	"strconv"
 	"strings"
-
+	"time"
 )
 
 const (
@@ -45,8 +48,8 @@ var (
 	ErrWorkbookRelsNotExist = errors.New("parse xlsx file failed: xl/_rels/workbook.xml.rels not exist")
 	// ErrWorkbookNotExist means can not found the workbook of excel.
 	ErrWorkbookNotExist = errors.New("parse xlsx file failed: xl/workbook.xml not exist")
-	// ErrSharedStringsNotExist means can not found the shared of excel.
-	ErrSharedStringsNotExist = errors.New("parse xlsx file failed: xl/sharedStringPaths.xml not exist")
+	// ErrSharedStringsNotExist means a cell refers to shared string but xl/sharedStrings.xml not exist.
+	ErrSharedStringsNotExist = errors.New("parse xlsx file failed: xl/sharedStrings.xml not exist")
 	// ErrInvalidConatiner means can not using the container.
 	ErrInvalidConatiner = errors.New("container should be ptr to slice")
 	// ErrEmptyRow means the row is empty.
@@ -57,16 +60,38 @@ var (
 	ErrScanNil = errors.New("scan(nil)")
 	// ErrDuplicatedTitles means the row of title has duplicated value and can not read into a map or struct since it need unique keys.
 	ErrDuplicatedTitles = errors.New("title row has duplicated key and can not read into a map or struct")
+	// ErrAmbiguousColumn means the column name (or its aliases) of a field matches more than one title.
+	ErrAmbiguousColumn = errors.New("column of field is ambiguous")
 )
 
+// DefaultSpoolThreshold is the default max bytes kept in memory by OpenReader.
+const DefaultSpoolThreshold = 32 << 20
+
+// tempFile is removed when close.
+type tempFile struct {
+	*os.File
+}
+
+func (f *tempFile) Close() error {
+	return errors.Join(f.File.Close(), os.Remove(f.File.Name()))
+}
+
 // connect is default implement of connector.
 type connect struct {
-	// list of sorted sheet name
-	sheets            []string
-	sharedStringPaths []string
+	option *connectOption
 
-	// xl/sharedStringPaths.xml
-	sharedStringPathsFile *zip.File
+	// list of sorted sheet name
+	sheets        []string
+	sharedStrings sharedStrings
+
+	// xl/sharedStrings.xml, may be nil
+	sharedStringsFile *zip.File
+	// xl/styles.xml, may be nil
+	stylesFile *zip.File
+	// whether each style in xl/styles.xml is formatted as date, the s attribute of cell is the index.
+	dateStyles []bool
+	// use 1904 date system
+	date1904 bool
 	// xl/_rels/workbook.xml.rels
 	workbookRels *zip.File
 	// map["rId*"]"xl/path/to/target";
@@ -81,53 +106,194 @@ type connect struct {
 	worksheetFileMap map[string]*zip.File
 	// map["sheet_name"]*zip.File
 	worksheetNameFileMap map[string]*zip.File
-	worksheetNameList    []string
-
+	// metadata of sheets in workbook order, the dimension is read when required.
+	sheetInfoList []SheetInfo
+	// whether the dimension of sheetInfoList is read
+	dimensionRead bool
+	// defined names of xl/workbook.xml
+	definedNameList []DefinedName
+
+	// sheets of the file opened, it's the connect itself for xlsx.
+	book workbook
 	// 实际的读取接口
 	zipReader *zip.Reader
-	// 仅读取文件时有效
-	zipReaderCloser io.ReadCloser
+	// 打开文件或临时文件时有效，关闭时一并关闭
+	zipReaderCloser io.Closer
+}
+
+type connectOption struct {
+	sharedStringsMode SharedStringsMode
+	// dir of temp files, use os.TempDir() if empty.
+	tempDir string
+	// map[scheme]Fetcher, used before the registered ones.
+	fetchers map[string]Fetcher
+	// OpenReader spool the input larger than it into a temp file, use DefaultSpoolThreshold if 0.
+	spoolThreshold int64
+	// open the file as csv if not nil, see NewCSVConnector.
+	csv *CSVConfig
+}
+
+// ConnectOption is the optional config of connector.
+type ConnectOption func(option *connectOption)
+
+// LoadSharedStrings decide how to load xl/sharedStrings.xml, default is SharedStringsEager.
+func LoadSharedStrings(mode SharedStringsMode) ConnectOption {
+	return func(option *connectOption) {
+		option.sharedStringsMode = mode
+	}
+}
+
+// TempDir set the dir of temp files, such as the shared strings spooled to disk.
+func TempDir(dir string) ConnectOption {
+	return func(option *connectOption) {
+		option.tempDir = dir
+	}
+}
+
+// SpoolThreshold set the max bytes kept in memory by OpenReader, the larger input is spooled into a temp file.
+func SpoolThreshold(n int64) ConnectOption {
+	return func(option *connectOption) {
+		option.spoolThreshold = n
+	}
+}
+
+// WithFetcher use fetcher for the scheme of uri in OpenFromUri of this connector only,
+// see RegisterFetcher to register for all connectors.
+func WithFetcher(scheme string, fetcher Fetcher) ConnectOption {
+	return func(option *connectOption) {
+		if option.fetchers == nil {
+			option.fetchers = make(map[string]Fetcher)
+		}
+		option.fetchers[strings.ToLower(scheme)] = fetcher
+	}
 }
 
 // NewConnector make a new connecter to connect to a exist xlsx file.
-func NewConnector() Connector {
-	return &connect{}
+func NewConnector(options ...ConnectOption) Connector {
+	conn := &connect{
+		option: &connectOption{},
+	}
+	for _, option := range options {
+		option(conn.option)
+	}
+	return conn
 }
 
 // Open a excel file
 func (conn *connect) Open(filePath string) error {
-	var err error
-	zipReaderCloser, err := zip.OpenReader(filePath)
+	f, err := os.Open(filePath)
 	if err != nil {
 		return err
 	}
-	conn.zipReader = &zipReaderCloser.Reader
-	// prepare for files
-	err = conn.init()
+	info, err := f.Stat()
 	if err != nil {
-		if conn.zipReaderCloser != nil {
-			conn.zipReaderCloser.Close()
-			conn.zipReaderCloser = nil
+		f.Close()
+		return err
+	}
+	return conn.openBook(f, info.Size(), f)
+}
+
+// OpenReaderAt open a excel from r of size bytes, r is not closed by the connector.
+func (conn *connect) OpenReaderAt(r io.ReaderAt, size int64) error {
+	return conn.openBook(r, size, nil)
+}
+
+// OpenReader read a excel from r, it's kept in memory if not larger than the spool threshold,
+// otherwise it's spooled into a temp file which is removed when close.
+func (conn *connect) OpenReader(r io.Reader) error {
+	threshold := conn.option.spoolThreshold
+	if threshold <= 0 {
+		threshold = DefaultSpoolThreshold
+	}
+	// read one more byte to know whether it's larger than threshold.
+	head, err := io.ReadAll(io.LimitReader(r, threshold+1))
+	if err != nil {
+		return err
+	}
+	if int64(len(head)) <= threshold {
+		return conn.OpenBinary(head)
+	}
+
+	tmp, err := os.CreateTemp(conn.option.tempDir, "excel-spool-*.xlsx")
+	if err != nil {
+		return err
+	}
+	f := &tempFile{File: tmp}
+	size, err := io.Copy(tmp, io.MultiReader(bytes.NewReader(head), r))
+	if err != nil {
+		f.Close()
+		return err
+	}
+	return conn.openBook(tmp, size, f)
+}
+
+// openBook open the workbook of r by its signature, the xls (Compound File Binary) or the zip of xlsx,
+// or as csv for the csv connector.
+// closer will be closed when the connector closed or failed to open.
+// The workbook opened before is closed, so nothing of it is kept.
+func (conn *connect) openBook(r io.ReaderAt, size int64, closer io.Closer) error {
+	if err := conn.Close(); err != nil {
+		if closer != nil {
+			closer.Close()
 		}
-		conn.zipReader = nil
 		return err
 	}
-	return nil
+	if conn.option.csv != nil {
+		conn.book = &csvBook{config: conn.option.csv, r: r, size: size, closer: closer}
+		return nil
+	}
+	header := make([]byte, len(cfbSignature))
+	if n, _ := r.ReadAt(header, 0); isCFB(header[:n]) {
+		return conn.openXLS(r, size, closer)
+	}
+	return conn.openZip(r, size, closer)
 }
 
-// Open a excel file from uri
-func (conn *connect) OpenFromUri(uri string) error {
-	buffer, err := loadFile(uri)
+// openXLS load the workbook stream of xls into memory, so closer is closed after that.
+func (conn *connect) openXLS(r io.ReaderAt, size int64, closer io.Closer) error {
+	if closer != nil {
+		defer closer.Close()
+	}
+	cfb, err := openCFB(r, size)
 	if err != nil {
 		return err
 	}
-	reader, err := zip.NewReader(bytes.NewReader(buffer), int64(len(buffer)))
+	book, err := openXLS(cfb)
 	if err != nil {
 		return err
 	}
+	conn.book = book
+	return nil
+}
+
+// openZip open the zip of xlsx or ods, closer will be closed when the connector closed or failed to open.
+func (conn *connect) openZip(r io.ReaderAt, size int64, closer io.Closer) error {
+	reader, err := zip.NewReader(r, size)
+	if err != nil {
+		if closer != nil {
+			closer.Close()
+		}
+		return err
+	}
 	conn.zipReader = reader
+	conn.zipReaderCloser = closer
+	if content, ok := isODS(reader); ok {
+		return conn.openODS(content)
+	}
 	// prepare for files
 	err = conn.init()
+	if err != nil {
+		// release the zip and the shared strings read before failed.
+		conn.Close()
+		return err
+	}
+	conn.book = conn
+	return nil
+}
+
+// openODS open the OpenDocument Spreadsheet in zip of connect.
+func (conn *connect) openODS(content *zip.File) error {
+	book, err := openODS(content)
 	if err != nil {
 		if conn.zipReaderCloser != nil {
 			conn.zipReaderCloser.Close()
@@ -136,55 +302,89 @@ func (conn *connect) OpenFromUri(uri string) error {
 		conn.zipReader = nil
 		return err
 	}
+	conn.book = book
 	return nil
 }
 
-// TODO: implement method
-func loadFile(uri string) (data []byte, err error) {
-	//data, err = StorageAdapter.GetFile(uri)
-	//if err != nil {
-	//	return
-	//}
-	return
+// Open a excel file from uri, the file is fetched by the Fetcher registered for its scheme,
+// such as "file:///path/to/file.xlsx" or "https://host/file.xlsx", the uri without scheme is a file path.
+func (conn *connect) OpenFromUri(uri string) error {
+	return conn.OpenFromUriContext(context.Background(), uri)
 }
 
-// OpenReader read a binary of xlsx file.
-func (conn *connect) OpenBinary(xlsxData []byte) error {
-	rd := bytes.NewReader(xlsxData)
-	var err error
-	conn.zipReader, err = zip.NewReader(rd, int64(rd.Len()))
-	if err != nil {
-		return err
+// Open a excel file from uri like OpenFromUri, the fetching is canceled when ctx is done.
+func (conn *connect) OpenFromUriContext(ctx context.Context, uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme == "" || len(u.Scheme) == 1 {
+		// file path, the single letter scheme is a volume of windows.
+		return conn.Open(uri)
 	}
-	// prepare for files
-	err = conn.init()
+	fetcher, ok := conn.option.fetchers[strings.ToLower(u.Scheme)]
+	if !ok {
+		fetcher, ok = getFetcher(u.Scheme)
+	}
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrFetcherNotExist, u.Scheme)
+	}
+	if _, ok = fetcher.(fileFetcher); ok {
+		// open the local file directly, it's not spooled like the fetched one.
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		path, err := fileURIPath(u)
+		if err != nil {
+			return err
+		}
+		return conn.Open(path)
+	}
+	rc, err := fetcher.Fetch(ctx, u)
 	if err != nil {
-		// 没有zipReader，不用Close
 		return err
 	}
-	return nil
+	defer rc.Close()
+	return conn.OpenReader(rc)
+}
+
+// OpenBinary read a binary of xlsx or xls file.
+func (conn *connect) OpenBinary(xlsxData []byte) error {
+	return conn.openBook(bytes.NewReader(xlsxData), int64(len(xlsxData)), nil)
 }
 
 // Close file reader
 func (conn *connect) Close() error {
+	// close every resource even if one of them failed.
+	var errs []error
+	if conn.book != nil && conn.book != workbook(conn) {
+		errs = append(errs, conn.book.close())
+	}
+	conn.book = nil
 	if conn.zipReaderCloser != nil {
-		err := conn.zipReaderCloser.Close()
-		if err != nil {
-			return err
-		}
+		errs = append(errs, conn.zipReaderCloser.Close())
 		conn.zipReaderCloser = nil
 	}
 	conn.zipReader = nil
 
-	conn.sheets = conn.sheets[:0]
-	conn.sharedStringPaths = conn.sharedStringPaths[:0]
-	conn.sharedStringPathsFile = nil
+	conn.sheets = nil
+	if conn.sharedStrings != nil {
+		errs = append(errs, conn.sharedStrings.close())
+		conn.sharedStrings = nil
+	}
+	conn.sharedStringsFile = nil
+	conn.stylesFile = nil
+	conn.dateStyles = nil
+	conn.date1904 = false
+	conn.workbookRels = nil
+	conn.workbookRelsIDMap = nil
 	conn.workbookFile = nil
 
+	conn.worksheetIDToNameMap = nil
 	conn.worksheetFileMap = nil
 	conn.worksheetNameFileMap = nil
+	conn.sheetInfoList = nil
+	conn.dimensionRead = false
+	conn.definedNameList = nil
 
-	return nil
+	return errors.Join(errs...)
 }
 
 // NewReader generate an new reader of a sheet
@@ -207,23 +407,93 @@ func (conn *connect) MustReader(sheetNamer interface{}) Reader {
 
 // NewReaderByConfig make a new reader by config
 func (conn *connect) NewReaderByConfig(config *Config) (Reader, error) {
-	if conn.zipReader == nil {
+	if conn.book == nil {
 		return nil, ErrConnectNotOpened
 	}
-	sheet := conn.parseSheetName(config.Sheet)
-	sheet = config.Prefix + sheet + config.Suffix
-	workSheetFile, ok := conn.worksheetNameFileMap[sheet]
-	if !ok {
+	var sheet string
+	var bounds *cellRange
+	if config.Range != "" {
+		var err error
+		if sheet, bounds, err = conn.parseRange(config.Range); err != nil {
+			return nil, err
+		}
+	}
+	if sheet == "" {
+		sheet = conn.parseSheetName(config.Sheet)
+		sheet = config.Prefix + sheet + config.Suffix
+	}
+	source, merges, err := conn.book.openSheet(sheet, config)
+	if err == ErrSheetNotExist {
 		return nil, fmt.Errorf("can not find worksheet named = %s", sheet)
 	}
-	rc, err := workSheetFile.Open()
 	if err != nil {
 		return nil, err
 	}
-	reader, err := newReader(conn, rc, config.TitleRowIndex, config.Skip)
+	reader, err := newReader(sheet, source, merges, bounds, conn.book.is1904(), config)
+	if err != nil {
+		source.close()
+	}
 	return reader, err
 }
 
+// parseRange parse the range of config, it's a reference such as "A1:D5" and "Sheet1!A10:D" or a defined name,
+// the sheet is empty if not specified by the range.
+func (conn *connect) parseRange(text string) (string, *cellRange, error) {
+	sheet, ref := "", text
+	if i := strings.LastIndex(text, "!"); i > 0 {
+		sheet, ref = unquoteSheetName(text[:i]), text[i+1:]
+	}
+	if bounds, err := parseCellRange(ref); err == nil {
+		return sheet, bounds, nil
+	}
+	sheet, ref, err := resolveDefinedName(conn.book.definedNames(), text)
+	if err != nil {
+		return "", nil, err
+	}
+	bounds, err := parseCellRange(ref)
+	return sheet, bounds, err
+}
+
+// sheetNameOf return the name of worksheet of xlsx by sheet id.
+func (conn *connect) sheetNameOf(id int) string {
+	return conn.worksheetIDToNameMap[strconv.Itoa(id)]
+}
+
+// close do nothing since the parts of xlsx are closed by Close.
+func (conn *connect) close() error {
+	return nil
+}
+
+// openSheet open the rows of worksheet of xlsx.
+func (conn *connect) openSheet(sheet string, config *Config) (rowSource, []*mergeCell, error) {
+	workSheetFile, ok := conn.worksheetNameFileMap[sheet]
+	if !ok {
+		return nil, nil, ErrSheetNotExist
+	}
+	var merges []*mergeCell
+	if config.FillMergedCells || config.TitleRowSpan > 1 {
+		var err error
+		if merges, err = conn.readMergeCells(workSheetFile); err != nil {
+			return nil, nil, err
+		}
+	}
+	rc, err := workSheetFile.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	location := config.Location
+	if location == nil {
+		location = time.UTC
+	}
+	sh, err := newXlsxRows(conn, rc, location)
+	if err != nil {
+		rc.Close()
+		return nil, nil, err
+	}
+	sh.size = int64(workSheetFile.UncompressedSize64)
+	return sh, merges, nil
+}
+
 // MustReaderByConfig panic insead of return error
 func (conn *connect) MustReaderByConfig(config *Config) Reader {
 	rd, err := conn.NewReaderByConfig(config)
@@ -235,19 +505,90 @@ func (conn *connect) MustReaderByConfig(config *Config) Reader {
 
 // GetSheetNames return the sheet names.
 func (conn *connect) GetSheetNames() []string {
-	if len(conn.worksheetNameFileMap) != len(conn.worksheetNameList) {
-		conn.worksheetNameList = make([]string, 0, len(conn.worksheetNameFileMap))
-		for name := range conn.worksheetNameFileMap {
-			conn.worksheetNameList = append(conn.worksheetNameList, name)
+	if conn.book == nil {
+		return nil
+	}
+	return conn.book.sheetNames()
+}
+
+// sheetNames return the names of worksheets of xlsx in workbook order.
+func (conn *connect) sheetNames() []string {
+	dst := make([]string, len(conn.sheets))
+	copy(dst, conn.sheets)
+	return dst
+}
+
+// GetSheetInfos return the metadata of sheets in workbook order.
+func (conn *connect) GetSheetInfos() ([]SheetInfo, error) {
+	if conn.book == nil {
+		return nil, ErrConnectNotOpened
+	}
+	return conn.book.sheetInfos()
+}
+
+// GetDefinedNames return the defined names of workbook.
+func (conn *connect) GetDefinedNames() []DefinedName {
+	if conn.book == nil {
+		return nil
+	}
+	return conn.book.definedNames()
+}
+
+// ResolveDefinedName return the sheet and range the defined name refers to.
+func (conn *connect) ResolveDefinedName(name string) (sheet string, ref string, err error) {
+	if conn.book == nil {
+		return "", "", ErrConnectNotOpened
+	}
+	return resolveDefinedName(conn.book.definedNames(), name)
+}
+
+// sheetInfos return the metadata of worksheets of xlsx, the dimension is read from the head of worksheet.
+func (conn *connect) sheetInfos() ([]SheetInfo, error) {
+	if !conn.dimensionRead {
+		for i := range conn.sheetInfoList {
+			info := &conn.sheetInfoList[i]
+			rc, err := conn.worksheetNameFileMap[info.Name].Open()
+			if err != nil {
+				return nil, err
+			}
+			info.Dimension, err = readDimension(rc)
+			rc.Close()
+			if err != nil {
+				return nil, err
+			}
+			info.Rows = dimensionRows(info.Dimension)
 		}
+		conn.dimensionRead = true
 	}
-	dst := make([]string, len(conn.worksheetNameList))
-	copy(dst, conn.worksheetNameList)
+	dst := make([]SheetInfo, len(conn.sheetInfoList))
+	copy(dst, conn.sheetInfoList)
+	return dst, nil
+}
+
+// is1904 report whether the date1904 of xl/workbook.xml is set.
+func (conn *connect) is1904() bool {
+	return conn.date1904
+}
+
+// definedNames return the defined names of xl/workbook.xml.
+func (conn *connect) definedNames() []DefinedName {
+	dst := make([]DefinedName, len(conn.definedNameList))
+	copy(dst, conn.definedNameList)
 	return dst
 }
 
-func (conn *connect) getSharedString(id int) string {
-	return conn.sharedStringPaths[id]
+// readMergeCells read the merged regions of worksheet file.
+func (conn *connect) readMergeCells(file *zip.File) ([]*mergeCell, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return readMergeCells(rc)
+}
+
+func (conn *connect) getSharedString(id int) (string, error) {
+	return conn.sharedStrings.get(id)
 }
 
 func (conn *connect) init() (err error) {
@@ -256,11 +597,13 @@ func (conn *connect) init() (err error) {
 	for _, f := range conn.zipReader.File {
 		switch f.Name {
 		case _SharedStringPath:
-			conn.sharedStringPathsFile = f
+			conn.sharedStringsFile = f
 		case _WorkBookPath:
 			conn.workbookFile = f
 		case _WorkBookRels:
 			conn.workbookRels = f
+		case _StylesPath:
+			conn.stylesFile = f
 		default:
 			if strings.HasPrefix(f.Name, _WorkSheetsPrefix) {
 				// log.Println("WorksheetName:", f.Name)
@@ -275,9 +618,6 @@ func (conn *connect) init() (err error) {
 	if conn.workbookFile == nil {
 		return ErrWorkbookNotExist
 	}
-	if conn.sharedStringPathsFile == nil {
-		return ErrSharedStringsNotExist
-	}
 	if conn.worksheetFileMap == nil || len(conn.worksheetFileMap) == 0 {
 		return ErrWorkbookNotExist
 	}
@@ -296,6 +636,11 @@ func (conn *connect) init() (err error) {
 	if err != nil {
 		return errors.New("read shared string failed:" + err.Error())
 	}
+	// prepare styles
+	err = conn.readStyles()
+	if err != nil {
+		return errors.New("read styles failed:" + err.Error())
+	}
 	return nil
 }
 
@@ -333,9 +678,11 @@ func (conn *connect) readWorkbook() error {
 		rc.Close()
 		return err
 	}
-	if conn.sheets == nil {
-		conn.sheets = make([]string, 0, len(wb.Sheets.Sheet))
-	}
+	conn.date1904 = wb.WorkbookPr.Date1904
+	// the workbook opened before is not kept.
+	conn.sheets = make([]string, 0, len(wb.Sheets.Sheet))
+	conn.sheetInfoList = make([]SheetInfo, 0, len(wb.Sheets.Sheet))
+	conn.definedNameList = make([]DefinedName, 0, len(wb.DefinedNames.DefinedName))
 	conn.worksheetNameFileMap = make(map[string]*zip.File, len(wb.Sheets.Sheet))
 	conn.worksheetIDToNameMap = make(map[string]string, len(wb.Sheets.Sheet))
 	for _, sheet := range wb.Sheets.Sheet {
@@ -353,99 +700,76 @@ func (conn *connect) readWorkbook() error {
 		// log.Println(sheet.Name)
 		conn.worksheetNameFileMap[sheet.Name] = file
 		conn.worksheetIDToNameMap[sheet.SheetID] = sheet.Name
+		conn.sheetInfoList = append(conn.sheetInfoList, SheetInfo{
+			Name:       sheet.Name,
+			Index:      len(conn.sheetInfoList) + 1,
+			Visibility: parseSheetState(sheet.State),
+		})
+	}
+	for _, name := range wb.DefinedNames.DefinedName {
+		dn := DefinedName{Name: name.Name, RefersTo: strings.TrimSpace(name.Value), Hidden: name.Hidden}
+		if name.LocalSheetID != nil && *name.LocalSheetID >= 0 && *name.LocalSheetID < len(wb.Sheets.Sheet) {
+			dn.Scope = wb.Sheets.Sheet[*name.LocalSheetID].Name
+		}
+		conn.definedNameList = append(conn.definedNameList, dn)
 	}
 	rc.Close()
 	return nil
 }
 
-func (conn *connect) readSharedString() error {
-	rc, err := conn.sharedStringPathsFile.Open()
+func (conn *connect) readStyles() error {
+	if conn.stylesFile == nil {
+		// no style, no date.
+		return nil
+	}
+	rc, err := conn.stylesFile.Open()
 	if err != nil {
 		return err
 	}
-	conn.sharedStringPaths, err = readSharedStringsXML(rc)
+	defer rc.Close()
+	styleSheet, err := readStyleSheetXML(rc)
 	if err != nil {
 		return err
 	}
-	rc.Close()
+	conn.dateStyles = styleSheet.dateStyles()
 	return nil
 }
 
-func readSharedStringsXML(rc io.ReadCloser) ([]string, error) {
-	decoder := xml.NewDecoder(rc)
-
-	tStart, rStart := false, false
-	var slc []string
-	siIndex := 0
-	for t, err := decoder.Token(); err == nil; t, err = decoder.Token() {
-		switch token := t.(type) {
-		case xml.StartElement:
-			switch token.Name.Local {
-			case _SI:
-				// don't enter default ...
-			case _T:
-				tStart = true
-			case _R:
-				rStart = true
-			case _SST:
-				count := 0
-				unqCount := 0
-				for _, attr := range token.Attr {
-					switch attr.Name.Local {
-					case _Count:
-						count, err = ToInt(attr.Value)
-						if err != nil {
-							return slc, err
-						}
-					case _UniqueCount:
-						unqCount, err = ToInt(attr.Value)
-						if err != nil {
-							return slc, err
-						}
-					}
-				}
-				if unqCount != 0 {
-					slc = make([]string, unqCount)
-				} else {
-					slc = make([]string, count)
-				}
-			default:
-				_ = decoder.Skip()
-			}
-		case xml.EndElement:
-			switch token.Name.Local {
-			case _SI:
-				siIndex++
-			case _T:
-				tStart = false
-			case _R:
-				rStart = false
-			}
-		case xml.CharData:
-			if len(slc) != 0 {
-				if tStart {
-					if rStart {
-						str := slc[siIndex]
-						slc[siIndex] = str + string(token)
-					} else {
-						slc[siIndex] = string(token)
-					}
-				}
-			}
-		}
+// isDateStyle report whether the style (the s attribute of cell) is formatted as date or time.
+func (conn *connect) isDateStyle(style string) bool {
+	if style == "" {
+		return false
 	}
-	return slc, nil
+	i, err := strconv.Atoi(style)
+	if err != nil || i < 0 || i >= len(conn.dateStyles) {
+		return false
+	}
+	return conn.dateStyles[i]
+}
+
+func (conn *connect) readSharedString() (err error) {
+	conn.sharedStrings, err = newSharedStrings(conn.sharedStringsFile, conn.option)
+	return err
 }
 
 func (conn *connect) parseSheetName(i interface{}) string {
 	switch s := i.(type) {
-	case string:
-		return s
 	case int, int8, int32, int64, uint, uint8, uint16, uint32, uint64:
-		if name, ok := conn.worksheetIDToNameMap[fmt.Sprintf("%d", s)]; ok {
-			return name
+		id, err := strconv.Atoi(fmt.Sprintf("%d", s))
+		if err != nil || conn.book == nil {
+			return ""
 		}
-		return ""
+		return conn.book.sheetNameOf(id)
+	default:
+		return inferSheetName(i)
+	}
+}
+
+// inferSheetName infer the sheet name by string, `GetXLSXSheetName()string` or the name of struct.
+func inferSheetName(i interface{}) string {
+	switch s := i.(type) {
+	case string:
+		return s
 	case interface {
 		GetXLSXSheetName() string
 	}:
@@ -459,10 +783,9 @@ func (conn *connect) parseSheetName(i interface{}) string {
 			if typ.Kind() == reflect.Ptr {
 				typ = typ.Elem()
 			}
-			return conn.parseSheetName(reflect.New(typ).Elem().Interface())
+			return inferSheetName(reflect.New(typ).Elem().Interface())
 		default:
 			return typ.Name()
 		}
-
 	}
 }
@@ -0,0 +1,89 @@
+package excel
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readStandardCount(t *testing.T, conn Connector) int {
+	t.Helper()
+	var list []Standard
+	rd := conn.MustReader(list)
+	defer rd.Close()
+	if err := rd.ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	return len(list)
+}
+
+func TestOpenClose(t *testing.T) {
+	conn := NewConnector().(*connect)
+	if err := conn.Open(TestFilePath); err != nil {
+		t.Fatal(err)
+	}
+	if conn.zipReaderCloser == nil {
+		t.Fatal("expect the file to be closed with connector")
+	}
+	if n := readStandardCount(t, conn); n != len(expectStandardList) {
+		t.Errorf("unexpect count: %d", n)
+	}
+	if err := conn.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if conn.zipReaderCloser != nil {
+		t.Error("expect the file closed")
+	}
+}
+
+func TestOpenReaderAt(t *testing.T) {
+	f, err := os.Open(TestFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn := NewConnector()
+	if err = conn.OpenReaderAt(f, info.Size()); err != nil {
+		t.Fatal(err)
+	}
+	if n := readStandardCount(t, conn); n != len(expectStandardList) {
+		t.Errorf("unexpect count: %d", n)
+	}
+	conn.Close()
+	// the reader is not closed by connector.
+	if _, err = f.Stat(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestOpenReader(t *testing.T) {
+	data, err := os.ReadFile(TestFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, threshold := range []int64{0, int64(len(data)), int64(len(data)) - 1} {
+		dir := t.TempDir()
+		conn := NewConnector(SpoolThreshold(threshold), TempDir(dir))
+		if err = conn.OpenReader(bytes.NewReader(data)); err != nil {
+			t.Fatal(err)
+		}
+		spooled, _ := filepath.Glob(filepath.Join(dir, "*"))
+		if expect := threshold == int64(len(data))-1; (len(spooled) == 1) != expect {
+			t.Errorf("threshold %d: expect spooled = %v but got: %v", threshold, expect, spooled)
+		}
+		if n := readStandardCount(t, conn); n != len(expectStandardList) {
+			t.Errorf("unexpect count: %d", n)
+		}
+		if err = conn.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if spooled, _ = filepath.Glob(filepath.Join(dir, "*")); len(spooled) != 0 {
+			t.Errorf("expect the temp file removed but got: %v", spooled)
+		}
+	}
+}
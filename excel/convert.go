@@ -7,6 +7,13 @@ import (
 	"time"
 )
 
+var (
+	// excel的1900日期系统以1899-12-30为0（兼容了1900年2月29日这个不存在的日期）
+	excelEpoch1900 = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	// excel的1904日期系统以1904-01-01为0
+	excelEpoch1904 = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
 // GetString 转换一个对象为string
 func GetString(value interface{}) string {
 	switch v := value.(type) {
@@ -1286,18 +1293,54 @@ func ToFloat64(value interface{}) (res float64, err error) {
 	return
 }
 
+// ToTime 将字符串转为time.Time
+// 支持RFC3339、"2006-01-02 15:04:05"、"2006-01-02"格式的文本，以及excel 1900日期系统的序列号（按UTC解析）
+// 空字符串转为零值
 func ToTime(v interface{}) (t time.Time, err error) {
 	switch fv := v.(type) {
+	case time.Time:
+		return fv, nil
 	case string:
-		t = GetXlsxTimeValues(fv)
-		return
+		return timeContext{}.toTime(fv)
 	default:
 		err = fmt.Errorf("不支持时间类型")
 	}
 	return
 }
 
+// timeContext is the date system and location of sheet to convert the cells to time.Time.
+type timeContext struct {
+	date1904 bool
+	// nil is time.UTC
+	location *time.Location
+}
+
+// toTime convert s like ToTime, the text without zone and the serial number are in the context.
+func (tc timeContext) toTime(s string) (t time.Time, err error) {
+	if s == "" {
+		return
+	}
+	loc := tc.location
+	if loc == nil {
+		loc = time.UTC
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err = time.ParseInLocation(layout, s, loc); err == nil {
+			return
+		}
+	}
+	var f float64
+	if f, err = strconv.ParseFloat(s, 64); err == nil {
+		t = excelTimeToTime(f, tc.date1904, loc)
+		return
+	}
+	err = fmt.Errorf("convert: %v to time failed.", s)
+	return
+}
+
 // Hack: 实现上有偏差 参考：https://blog.csdn.net/qq_15043089/article/details/118612717#circle=on
+//
+// Deprecated: 固定使用了UTC+8且不支持1904日期系统，读取时已按单元格样式转换日期，请使用ToTime。
 func GetXlsxTimeValues(xlsxTime string) (localTime time.Time) {
 	fTime, _ := strconv.ParseFloat(xlsxTime, 64)
 	nTime := int(math.Floor(fTime))
@@ -1306,3 +1349,28 @@ func GetXlsxTimeValues(xlsxTime string) (localTime time.Time) {
 	localTime = time.Unix(prevMonth.Unix()+int64(math.Floor((fTime-float64(nTime))*86400))-28800, 0)
 	return
 }
+
+// timeToExcelTime convert t to the serial number of excel in 1900 date system,
+// the wall clock of t is used as excel does not know the location.
+func timeToExcelTime(t time.Time) float64 {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	sec := wall.Unix() - excelEpoch1900.Unix()
+	return float64(sec)/86400 + float64(wall.Nanosecond())/float64(24*time.Hour)
+}
+
+// excelTimeToTime convert the serial number of excel to the wall clock in loc.
+func excelTimeToTime(serial float64, date1904 bool, loc *time.Location) time.Time {
+	epoch := excelEpoch1900
+	if date1904 {
+		epoch = excelEpoch1904
+	} else if serial >= 1 && serial < 60 {
+		// 1900-03-01 is 61, the days before 1900-02-29 (60, not exist) are shifted by one day,
+		// the serial less than 1 is only time.
+		serial++
+	}
+	days := math.Floor(serial)
+	// round to millisecond, the precision of excel
+	ms := int(math.Round((serial - days) * 86400000))
+	return time.Date(epoch.Year(), epoch.Month(), epoch.Day()+int(days),
+		0, 0, ms/1000, ms%1000*int(time.Millisecond), loc)
+}
@@ -3,6 +3,7 @@ package excel
 import (
 	"strconv"
 	"testing"
+	"time"
 )
 
 func TestToBool(t *testing.T) {
@@ -216,3 +217,51 @@ func TestToFloat64(t *testing.T) {
 		})
 	}
 }
+
+func TestToTime(t *testing.T) {
+	tests := []struct {
+		args    interface{}
+		wantRes time.Time
+	}{
+		{
+			"",
+			time.Time{},
+		},
+		{
+			"2022-10-11T12:00:29Z",
+			time.Date(2022, 10, 11, 12, 0, 29, 0, time.UTC),
+		},
+		{
+			"2022-10-11 12:00:29",
+			time.Date(2022, 10, 11, 12, 0, 29, 0, time.UTC),
+		},
+		{
+			"2022-10-11",
+			time.Date(2022, 10, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			"44845.500335648147",
+			time.Date(2022, 10, 11, 12, 0, 29, 0, time.UTC),
+		},
+		{
+			"1",
+			time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			"61",
+			time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for i, tt := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			gotRes, err := ToTime(tt.args)
+			if err != nil {
+				t.Errorf("ToTime() error = %v ", err)
+				return
+			}
+			if !gotRes.Equal(tt.wantRes) {
+				t.Errorf("ToTime() gotRes = %v, want %v", gotRes, tt.wantRes)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package excel
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ErrConverterNotExist means the converter named by tag conv(name) is not registered.
+var ErrConverterNotExist = errors.New("converter not exist")
+
+// Converter convert the text of cell into a value, the value should be assignable to the field.
+type Converter func(s string) (interface{}, error)
+
+var (
+	convertersMu sync.RWMutex
+	// map[type]Converter, used by every field of the type
+	typeConverters = make(map[reflect.Type]Converter)
+	// map[name]Converter, used by the field with tag conv(name)
+	namedConverters = make(map[string]Converter)
+)
+
+// RegisterConverter register converter for the fields of type t, such as reflect.TypeOf(decimal.Decimal{}),
+// it's used before the built-in conversions, and so does the pointer to t and the element of slice.
+// It's safe to call concurrently, and a nil converter unregister the type.
+func RegisterConverter(t reflect.Type, converter func(s string) (interface{}, error)) {
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+	if converter == nil {
+		delete(typeConverters, t)
+		return
+	}
+	typeConverters[t] = converter
+}
+
+// RegisterNamedConverter register converter for the fields with tag `xlsx:"conv(name)"`.
+// It's safe to call concurrently, and a nil converter unregister the name.
+func RegisterNamedConverter(name string, converter func(s string) (interface{}, error)) {
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+	if converter == nil {
+		delete(namedConverters, name)
+		return
+	}
+	namedConverters[name] = converter
+}
+
+func getTypeConverter(t reflect.Type) (Converter, bool) {
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+	if len(typeConverters) == 0 {
+		return nil, false
+	}
+	converter, ok := typeConverters[t]
+	return converter, ok
+}
+
+func getNamedConverter(name string) (Converter, error) {
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+	converter, ok := namedConverters[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrConverterNotExist, name)
+	}
+	return converter, nil
+}
+
+// convertTo convert s by converter and set the result into v.
+func convertTo(converter Converter, s string, v reflect.Value) error {
+	value, err := converter(s)
+	if err != nil {
+		return err
+	}
+	return assignConverted(value, v)
+}
+
+// assignConverted set value into v, the pointer is allocated or dereferenced if required.
+func assignConverted(value interface{}, v reflect.Value) error {
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	switch {
+	case rv.Type().AssignableTo(v.Type()):
+		v.Set(rv)
+	case v.Kind() == reflect.Ptr && rv.Type().AssignableTo(v.Type().Elem()):
+		ptr := reflect.New(v.Type().Elem())
+		ptr.Elem().Set(rv)
+		v.Set(ptr)
+	case rv.Kind() == reflect.Ptr && rv.Type().Elem().AssignableTo(v.Type()):
+		if rv.IsNil() {
+			v.Set(reflect.Zero(v.Type()))
+		} else {
+			v.Set(rv.Elem())
+		}
+	default:
+		return fmt.Errorf("converted value of %T is not assignable to %s", value, v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package excel
+
+import (
+	"database/sql"
+	"errors"
+	"math/big"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Money is a type we don't own, it's converted by the registered converter.
+type Money struct {
+	Cents int64
+}
+
+type Payment struct {
+	ID     int
+	Amount Money
+	Refund *Money
+	Fees   []Money  `xlsx:"split(|)"`
+	Payer  string   `xlsx:"conv(upper)"`
+	Tags   []string `xlsx:"split(|);conv(upper)"`
+	IP     net.IP
+	Big    *big.Int
+	Note   sql.NullString
+	Count  sql.NullInt64
+}
+
+func TestConverter(t *testing.T) {
+	RegisterConverter(reflect.TypeOf(Money{}), func(s string) (interface{}, error) {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, err
+		}
+		return Money{Cents: int64(f*100 + 0.5)}, nil
+	})
+	RegisterNamedConverter("upper", func(s string) (interface{}, error) {
+		return strings.ToUpper(s), nil
+	})
+	defer RegisterConverter(reflect.TypeOf(Money{}), nil)
+	defer RegisterNamedConverter("upper", nil)
+
+	conn := NewCSVConnector(nil)
+	if err := conn.OpenBinary([]byte("ID,Amount,Refund,Fees,Payer,Tags,IP,Big,Note,Count\n" +
+		"1,12.34,0.5,1|2.5,andy,a|b,10.0.0.1,123456789012345678901234567890,paid,3\n" +
+		"2,1,,,,,,,,\n")); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var list []Payment
+	if err := conn.MustReader(&list).ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	b, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	expectList := []Payment{
+		{
+			ID:     1,
+			Amount: Money{Cents: 1234},
+			Refund: &Money{Cents: 50},
+			Fees:   []Money{{Cents: 100}, {Cents: 250}},
+			Payer:  "ANDY",
+			Tags:   []string{"A", "B"},
+			IP:     net.ParseIP("10.0.0.1"),
+			Big:    b,
+			Note:   sql.NullString{String: "paid", Valid: true},
+			Count:  sql.NullInt64{Int64: 3, Valid: true},
+		},
+		{ID: 2, Amount: Money{Cents: 100}},
+	}
+	if !reflect.DeepEqual(list, expectList) {
+		t.Errorf("unexpect list: \n%s", MustJsonPrettyString(list))
+	}
+}
+
+type UnknownConverter struct {
+	ID int `xlsx:"conv(not-exist)"`
+}
+
+func TestConverterNotExist(t *testing.T) {
+	conn := NewCSVConnector(nil)
+	if err := conn.OpenBinary([]byte("ID\n1\n")); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var list []UnknownConverter
+	if err := conn.MustReader(&list).ReadAll(&list); !errors.Is(err, ErrConverterNotExist) {
+		t.Errorf("expect ErrConverterNotExist but got: %v", err)
+	}
+}
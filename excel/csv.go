@@ -0,0 +1,262 @@
+package excel
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/transform"
+)
+
+// CSVQuoting decide how the quotes in csv are parsed.
+type CSVQuoting int
+
+const (
+	// CSVQuoteStrict parse the quoted fields as RFC 4180, a bare quote in field is an error, it's the default.
+	CSVQuoteStrict CSVQuoting = iota
+	// CSVQuoteLazy allow a quote in unquoted field and a non-doubled quote in quoted field.
+	CSVQuoteLazy
+	// CSVQuoteNone treat the quotes as normal chars, every line is a row split by the delimiter,
+	// it's common for TSV.
+	CSVQuoteNone
+)
+
+// _CSVSheetName is the default name of the only sheet of csv.
+const _CSVSheetName = "Sheet1"
+
+// CSVConfig of the csv connector.
+type CSVConfig struct {
+	// Delimiter of fields, default is ',', use '\t' for TSV.
+	Comma rune
+	// The lines start with Comment are ignored, no comment if 0.
+	Comment rune
+	// How the quotes are parsed, default is CSVQuoteStrict.
+	Quoting CSVQuoting
+	// Encoding of the file, such as simplifiedchinese.GBK, default is UTF-8.
+	// The leading BOM is always removed after decoding.
+	Encoding encoding.Encoding
+	// Index of the header row, every row before it will be ignored, default is 0.
+	// The row numbers in errors are still the lines of file.
+	HeaderRow int
+	// Name of the only sheet, default is "Sheet1".
+	// Any sheet name is accepted by NewReader, so the code of reading xlsx works unchanged.
+	SheetName string
+}
+
+// NewCSVConnector make a new connecter to connect to a csv (or TSV) file, the file is treated as a single sheet.
+// The fields are read as string cells, and the empty fields are empty cells.
+func NewCSVConnector(config *CSVConfig, options ...ConnectOption) Connector {
+	conn := NewConnector(options...).(*connect)
+	csvConfig := CSVConfig{}
+	if config != nil {
+		csvConfig = *config
+	}
+	if csvConfig.Comma == 0 {
+		csvConfig.Comma = ','
+	}
+	if csvConfig.SheetName == "" {
+		csvConfig.SheetName = _CSVSheetName
+	}
+	conn.option.csv = &csvConfig
+	return conn
+}
+
+// csvBook is the workbook of csv, every reader parses the file from the beginning.
+type csvBook struct {
+	config *CSVConfig
+	r      io.ReaderAt
+	size   int64
+	closer io.Closer
+}
+
+func (book *csvBook) sheetNames() []string {
+	return []string{book.config.SheetName}
+}
+
+func (book *csvBook) sheetNameOf(i int) string {
+	if i != 1 {
+		return ""
+	}
+	return book.config.SheetName
+}
+
+// sheetInfos return the only sheet, the dimension is unknown before parsing the whole file.
+func (book *csvBook) sheetInfos() ([]SheetInfo, error) {
+	return []SheetInfo{{Name: book.config.SheetName, Index: 1, Visibility: SheetVisible}}, nil
+}
+
+// is1904 return false, the serial numbers in csv are read in the 1900 date system.
+func (book *csvBook) is1904() bool {
+	return false
+}
+
+// definedNames return nil since csv has no defined name.
+func (book *csvBook) definedNames() []DefinedName {
+	return nil
+}
+
+// openSheet open the only sheet whatever the sheet name is.
+func (book *csvBook) openSheet(sheet string, config *Config) (rowSource, []*mergeCell, error) {
+	rows := newCSVRows(book.config, io.NewSectionReader(book.r, 0, book.size), book.size)
+	for i := 0; i < book.config.HeaderRow; i++ {
+		if _, err := rows.readRow(); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, nil, err
+		}
+	}
+	return rows, nil, nil
+}
+
+func (book *csvBook) close() error {
+	if book.closer != nil {
+		return book.closer.Close()
+	}
+	return nil
+}
+
+// countReader count the bytes read.
+type countReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// csvRows is the rows of csv, the records are parsed when read.
+type csvRows struct {
+	counter *countReader
+	size    int64
+	// read the next record and the line it starts at
+	readRecord func() ([]string, int, error)
+	// the record at cursor
+	fields []string
+	// the error of the record at cursor
+	err error
+	// whether the record at cursor is not read
+	pending bool
+	// no more record can be read
+	done bool
+	line int
+}
+
+func newCSVRows(config *CSVConfig, r io.Reader, size int64) *csvRows {
+	counter := &countReader{r: r}
+	var in io.Reader = counter
+	if config.Encoding != nil {
+		in = transform.NewReader(in, config.Encoding.NewDecoder())
+	}
+	br := bufio.NewReader(in)
+	if c, _, err := br.ReadRune(); err == nil && c != '\uFEFF' {
+		br.UnreadRune()
+	}
+	rows := &csvRows{counter: counter, size: size}
+	if config.Quoting == CSVQuoteNone {
+		rows.readRecord = lineRecordReader(br, config)
+		return rows
+	}
+	reader := csv.NewReader(br)
+	reader.Comma = config.Comma
+	reader.Comment = config.Comment
+	reader.LazyQuotes = config.Quoting == CSVQuoteLazy
+	// the rows may have different number of fields
+	reader.FieldsPerRecord = -1
+	rows.readRecord = func() ([]string, int, error) {
+		record, err := reader.Read()
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return nil, parseErr.StartLine, err
+			}
+			return nil, 0, err
+		}
+		line, _ := reader.FieldPos(0)
+		return record, line, nil
+	}
+	return rows
+}
+
+// lineRecordReader split every line by the delimiter, the empty lines and comments are skipped.
+func lineRecordReader(br *bufio.Reader, config *CSVConfig) func() ([]string, int, error) {
+	line := 0
+	comma := string(config.Comma)
+	return func() ([]string, int, error) {
+		for {
+			s, err := br.ReadString('\n')
+			if s == "" && err != nil {
+				return nil, 0, err
+			}
+			line++
+			s = strings.TrimSuffix(strings.TrimSuffix(s, "\n"), "\r")
+			if s == "" || (config.Comment != 0 && strings.HasPrefix(s, string(config.Comment))) {
+				continue
+			}
+			return strings.Split(s, comma), line, nil
+		}
+	}
+}
+
+func (sh *csvRows) next() bool {
+	if sh.done {
+		return false
+	}
+	fields, line, err := sh.readRecord()
+	if err == io.EOF {
+		sh.done = true
+		sh.pending = false
+		return false
+	}
+	var parseErr *csv.ParseError
+	if err != nil && !errors.As(err, &parseErr) {
+		// the error can not be recovered, such as a bad delimiter or failed to decode.
+		sh.done = true
+	}
+	sh.fields, sh.err, sh.line, sh.pending = fields, err, line, true
+	return true
+}
+
+func (sh *csvRows) readRow() ([]*rowCell, error) {
+	if !sh.pending && !sh.next() {
+		return nil, io.EOF
+	}
+	sh.pending = false
+	if sh.err != nil {
+		return nil, sh.err
+	}
+	row := strconv.Itoa(sh.line)
+	cells := make([]*rowCell, 0, len(sh.fields))
+	for i, field := range sh.fields {
+		if field == "" {
+			continue
+		}
+		cells = append(cells, &rowCell{
+			Cell:        Cell{Type: CellTypeString, Value: field, Ref: ToColumnName(i) + row},
+			columnIndex: i,
+		})
+	}
+	return cells, nil
+}
+
+func (*csvRows) linedRows() {}
+
+func (sh *csvRows) rowNumber() int {
+	return sh.line
+}
+
+func (sh *csvRows) progress() (consumed, total int64) {
+	return sh.counter.n, sh.size
+}
+
+func (sh *csvRows) close() error {
+	sh.done = true
+	return nil
+}
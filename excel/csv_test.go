@@ -0,0 +1,155 @@
+package excel
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+type CSVRow struct {
+	ID    int      `xlsx:"column(ID)"`
+	Name  string   `xlsx:"column(Name);req()"`
+	Score *float64 `xlsx:"column(Score);nil(N/A)"`
+	Tags  []string `xlsx:"column(Tags);split(|)"`
+	Level string   `xlsx:"column(Level);default(low)"`
+}
+
+func TestReadCSV(t *testing.T) {
+	data := "\uFEFFexported by finance\n" +
+		"ID,Name,Score,Tags,Level\n" +
+		"1,Andy,98.5,a|b,high\n" +
+		"\n" +
+		"2,\"Leo, Jr.\",N/A,,\n" +
+		"3,\"multi\nline\",60,c,\n"
+	conn := NewCSVConnector(&CSVConfig{HeaderRow: 1})
+	if err := conn.OpenBinary([]byte(data)); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if names := conn.GetSheetNames(); !reflect.DeepEqual(names, []string{"Sheet1"}) {
+		t.Errorf("unexpect sheet names: %v", names)
+	}
+	// the sheet name inferred from CSVRow is accepted.
+	rd, err := conn.NewReader(&[]CSVRow{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	if titles := rd.GetTitles(); !reflect.DeepEqual(titles, []string{"ID", "Name", "Score", "Tags", "Level"}) {
+		t.Errorf("unexpect titles: %v", titles)
+	}
+	var list []CSVRow
+	if err = rd.ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	score1, score3 := 98.5, 60.0
+	expectList := []CSVRow{
+		{ID: 1, Name: "Andy", Score: &score1, Tags: []string{"a", "b"}, Level: "high"},
+		{ID: 2, Name: "Leo, Jr.", Level: "low"},
+		{ID: 3, Name: "multi\nline", Score: &score3, Tags: []string{"c"}, Level: "low"},
+	}
+	if !reflect.DeepEqual(list, expectList) {
+		t.Errorf("unexpect list: \n%s", MustJsonPrettyString(list))
+	}
+
+	// the rows are parsed again by a new reader.
+	var cells []map[string]Cell
+	if err = conn.MustReader(1).ReadAll(&cells); err != nil {
+		t.Fatal(err)
+	}
+	if len(cells) != 3 || cells[2]["Name"].Ref != "B6" || cells[2]["Name"].Type != CellTypeString {
+		t.Errorf("unexpect cells: \n%s", MustJsonPrettyString(cells))
+	}
+}
+
+func TestReadTSV(t *testing.T) {
+	data, err := simplifiedchinese.GBK.NewEncoder().String("编号\t名称\t备注\n1\t张三\t\"quoted\"\n2\t李四\t\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn := NewCSVConnector(&CSVConfig{Comma: '\t', Quoting: CSVQuoteNone, Encoding: simplifiedchinese.GBK})
+	if err := conn.OpenReader(strings.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var list []map[string]string
+	if err := conn.MustReader("Sheet1").ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	expectList := []map[string]string{
+		{"编号": "1", "名称": "张三", "备注": `"quoted"`},
+		{"编号": "2", "名称": "李四", "备注": ""},
+	}
+	if !reflect.DeepEqual(list, expectList) {
+		t.Errorf("unexpect list: \n%s", MustJsonPrettyString(list))
+	}
+}
+
+func TestReadCSVParseError(t *testing.T) {
+	conn := NewCSVConnector(nil)
+	if err := conn.OpenReaderAt(bytes.NewReader([]byte("ID,Name\n1,a\"b\n2,c\n")), 18); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var list []map[string]string
+	err := conn.MustReader("Sheet1").ReadAll(&list)
+	var parseErr *csv.ParseError
+	if !errors.As(err, &parseErr) || parseErr.StartLine != 2 {
+		t.Errorf("expect parse error of line 2 but got: %v", err)
+	}
+
+	// the bare quote is allowed by CSVQuoteLazy.
+	conn = NewCSVConnector(&CSVConfig{Quoting: CSVQuoteLazy})
+	if err := conn.OpenBinary([]byte("ID,Name\n1,a\"b\n2,c\n")); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	list = nil
+	if err := conn.MustReader("Sheet1").ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0]["Name"] != `a"b` {
+		t.Errorf("unexpect list: %v", list)
+	}
+}
+
+type CSVNote struct {
+	ID   int
+	Note string
+}
+
+func TestReadCSVEmptyRowPolicy(t *testing.T) {
+	// the quoted field takes two lines, no row is omitted before the next record.
+	conn := NewCSVConnector(nil)
+	if err := conn.OpenReader(strings.NewReader("ID,Note\n1,\"a\nb\"\n2,c\n,\n3,d\n")); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	for policy, expect := range map[EmptyRowPolicy][]CSVNote{
+		EmptyRowSkip: {{1, "a\nb"}, {2, "c"}, {3, "d"}},
+		EmptyRowStop: {{1, "a\nb"}, {2, "c"}},
+		EmptyRowZero: {{1, "a\nb"}, {2, "c"}, {}, {3, "d"}},
+	} {
+		rd, err := conn.NewReaderByConfig(&Config{Sheet: "Sheet1", EmptyRowPolicy: policy})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var list []CSVNote
+		if err = rd.ReadAll(&list); err != nil {
+			t.Fatal(err)
+		}
+		rd.Close()
+		if !reflect.DeepEqual(list, expect) {
+			t.Errorf("unexpect list of policy %d: %v", policy, list)
+		}
+	}
+}
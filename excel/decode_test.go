@@ -5,13 +5,14 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 	"time"
 )
 
 var (
-	TestFileUri    = "10/sea/37/f4/5914da82c3f80263989b93ae6ed2"
 	TestFilePath   = "./testdata/simple.xlsx"
 	StdSheetName   = "Standard"
 	AdvSheetName   = "Advance"
@@ -19,8 +20,12 @@ var (
 	DupSheetName   = "DuplicatedTitle"
 )
 
+// StdTime is the value of Time column in Standard sheet.
+var StdTime = time.Date(2022, 10, 11, 12, 0, 29, 0, time.UTC)
+
 var expectStandardList = []Standard{
 	{
+		Time:    StdTime,
 		ID:      1,
 		Name:    "Andy",
 		NamePtr: StrPtr("Andy"),
@@ -31,6 +36,7 @@ var expectStandardList = []Standard{
 		},
 	},
 	{
+		Time:    StdTime,
 		ID:      2,
 		Name:    "Leo",
 		NamePtr: StrPtr("Leo"),
@@ -64,6 +70,7 @@ var expectStandardList = []Standard{
 
 var expectStandardPtrList = []*Standard{
 	{
+		Time:    StdTime,
 		ID:      1,
 		Name:    "Andy",
 		NamePtr: StrPtr("Andy"),
@@ -74,6 +81,7 @@ var expectStandardPtrList = []*Standard{
 		},
 	},
 	{
+		Time:    StdTime,
 		ID:      2,
 		Name:    "Leo",
 		NamePtr: StrPtr("Leo"),
@@ -108,6 +116,7 @@ var expectStandardPtrList = []*Standard{
 var expectStandardMapList = []map[string]string{
 	{
 		"ID":              "1",
+		"Time":            "2022-10-11T12:00:29Z",
 		"NameOf":          "Andy",
 		"AgeOf":           "1",
 		"Slice":           "1|2",
@@ -115,6 +124,7 @@ var expectStandardMapList = []map[string]string{
 	},
 	{
 		"ID":              "2",
+		"Time":            "2022-10-11T12:00:29Z",
 		"NameOf":          "Leo",
 		"AgeOf":           "2",
 		"Slice":           "2|3|4",
@@ -122,6 +132,7 @@ var expectStandardMapList = []map[string]string{
 	},
 	{
 		"ID":              "3",
+		"Time":            "",
 		"NameOf":          "Ben",
 		"AgeOf":           "3",
 		"Slice":           "3|4|5|6",
@@ -129,6 +140,7 @@ var expectStandardMapList = []map[string]string{
 	},
 	{
 		"ID":              "4",
+		"Time":            "",
 		"NameOf":          "Ming",
 		"AgeOf":           "4",
 		"Slice":           "1",
@@ -139,6 +151,7 @@ var expectStandardMapList = []map[string]string{
 var expectStandardSliceList = [][]string{
 	{
 		"1",
+		"2022-10-11T12:00:29Z",
 		"Andy",
 		"1",
 		"1|2",
@@ -146,6 +159,7 @@ var expectStandardSliceList = [][]string{
 	},
 	{
 		"2",
+		"2022-10-11T12:00:29Z",
 		"Leo",
 		"2",
 		"2|3|4",
@@ -153,6 +167,7 @@ var expectStandardSliceList = [][]string{
 	},
 	{
 		"3",
+		"",
 		"Ben",
 		"3",
 		"3|4|5|6",
@@ -160,6 +175,7 @@ var expectStandardSliceList = [][]string{
 	},
 	{
 		"4",
+		"",
 		"Ming",
 		"4",
 		"1",
@@ -198,6 +214,10 @@ func (tmp *Temp) UnmarshalBinary(d []byte) error {
 	return json.Unmarshal(d, tmp)
 }
 
+func (tmp *Temp) MarshalBinary() ([]byte, error) {
+	return json.Marshal(tmp)
+}
+
 func init() {
 	log.SetFlags(log.Llongfile)
 }
@@ -353,8 +373,11 @@ func TestReadStandardPtrAll(t *testing.T) {
 }
 
 func TestReadStandardPtrAllFromUri(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir("./testdata")))
+	defer server.Close()
+
 	conn := NewConnector()
-	err := conn.OpenFromUri(TestFileUri)
+	err := conn.OpenFromUri(server.URL + "/simple.xlsx")
 	if err != nil {
 		t.Error(err)
 		return
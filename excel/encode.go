@@ -0,0 +1,45 @@
+package excel
+
+import (
+	"bytes"
+	"os"
+)
+
+// Marshal write container into a XLSX binary.
+// The sheet name will be inferred from element of container
+// If container implement the function of GetXLSXSheetName()string, the return string will used.
+// Oterwise will use the reflect struct name.
+func Marshal(container interface{}) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	w := NewWriter(buffer)
+	err := w.WriteAll(container)
+	if err != nil {
+		return nil, err
+	}
+	err = w.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// MarshalXLSX write container into a sheet of XLSX file, the file will be truncated if exist.
+func MarshalXLSX(filePath string, container interface{}) error {
+	f, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+
+	w := NewWriter(f)
+	err = w.WriteAll(container)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	err = w.Close()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,183 @@
+package excel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrFetcherNotExist means no fetcher is registered for the scheme of uri.
+	ErrFetcherNotExist = errors.New("fetcher of the uri scheme not exist")
+	// ErrFileTooLarge means the fetched file is larger than the limit.
+	ErrFileTooLarge = errors.New("file is too large")
+)
+
+const (
+	// DefaultFetchTimeout is the timeout of the built-in http(s) fetcher.
+	DefaultFetchTimeout = 5 * time.Minute
+	// DefaultFetchMaxSize is the max size of file fetched by the built-in http(s) fetcher.
+	DefaultFetchMaxSize = 1 << 30
+)
+
+// Fetcher fetch the content of a uri for Connector.OpenFromUri.
+type Fetcher interface {
+	// Fetch the content of uri, the returned reader will be closed after read.
+	Fetch(ctx context.Context, uri *url.URL) (io.ReadCloser, error)
+}
+
+// FetcherFunc is an adapter to use a func as Fetcher.
+type FetcherFunc func(ctx context.Context, uri *url.URL) (io.ReadCloser, error)
+
+// Fetch call f(ctx, uri).
+func (f FetcherFunc) Fetch(ctx context.Context, uri *url.URL) (io.ReadCloser, error) {
+	return f(ctx, uri)
+}
+
+var (
+	fetchersMu sync.RWMutex
+	// map[scheme]Fetcher
+	fetchers = map[string]Fetcher{
+		"file":  fileFetcher{},
+		"http":  &HTTPFetcher{Timeout: DefaultFetchTimeout, MaxSize: DefaultFetchMaxSize},
+		"https": &HTTPFetcher{Timeout: DefaultFetchTimeout, MaxSize: DefaultFetchMaxSize},
+	}
+)
+
+// RegisterFetcher register fetcher for the scheme of uri (such as "s3"), the built-in one will be replaced.
+// It's safe to call concurrently, and a nil fetcher unregister the scheme.
+func RegisterFetcher(scheme string, fetcher Fetcher) {
+	fetchersMu.Lock()
+	defer fetchersMu.Unlock()
+	scheme = strings.ToLower(scheme)
+	if fetcher == nil {
+		delete(fetchers, scheme)
+		return
+	}
+	fetchers[scheme] = fetcher
+}
+
+func getFetcher(scheme string) (Fetcher, bool) {
+	fetchersMu.RLock()
+	defer fetchersMu.RUnlock()
+	fetcher, ok := fetchers[strings.ToLower(scheme)]
+	return fetcher, ok
+}
+
+// fileFetcher open the local file of file:///path/to/file.xlsx,
+// OpenFromUri opens the file directly instead of fetching it if the built-in one is used.
+type fileFetcher struct{}
+
+func (fileFetcher) Fetch(ctx context.Context, uri *url.URL) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	path, err := fileURIPath(uri)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+// fileURIPath return the local path of file uri, such as "/C:/dir/file.xlsx" to "C:\dir\file.xlsx" on windows.
+func fileURIPath(uri *url.URL) (string, error) {
+	if uri.Host != "" && uri.Host != "localhost" {
+		return "", fmt.Errorf("file uri of remote host %s is not supported", uri.Host)
+	}
+	path := uri.Path
+	if len(path) >= 3 && path[0] == '/' && path[2] == ':' && isLetter(path[1]) {
+		// the drive letter of windows
+		path = path[1:]
+	}
+	return filepath.FromSlash(path), nil
+}
+
+// HTTPFetcher fetch the http(s) uri, the zero value is ready to use but has no timeout and size limit.
+// The built-in fetcher of http(s) uses DefaultFetchTimeout and DefaultFetchMaxSize,
+// register a configured one with RegisterFetcher or WithFetcher to change them.
+type HTTPFetcher struct {
+	// Client to send request, use http.DefaultClient if nil.
+	Client *http.Client
+	// Timeout of the whole request including reading body, no timeout if 0.
+	Timeout time.Duration
+	// MaxSize of the response body, ErrFileTooLarge is returned if exceeded, no limit if 0.
+	MaxSize int64
+}
+
+// Fetch get the uri and return the body if status is 2xx.
+func (f *HTTPFetcher) Fetch(ctx context.Context, uri *url.URL) (io.ReadCloser, error) {
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	cancel := func() {}
+	if f.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, f.Timeout)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("fetch %s failed: %s", uri.Redacted(), resp.Status)
+	}
+	if f.MaxSize > 0 && resp.ContentLength > f.MaxSize {
+		resp.Body.Close()
+		cancel()
+		return nil, ErrFileTooLarge
+	}
+	body := resp.Body
+	if f.MaxSize > 0 {
+		body = &limitedReadCloser{ReadCloser: body, remain: f.MaxSize}
+	}
+	// the timeout covers reading the body, so cancel it after close.
+	return &cancelReadCloser{ReadCloser: body, cancel: cancel}, nil
+}
+
+// limitedReadCloser return ErrFileTooLarge if more than remain bytes are read.
+type limitedReadCloser struct {
+	io.ReadCloser
+	remain int64
+}
+
+func (r *limitedReadCloser) Read(p []byte) (int, error) {
+	if r.remain < 0 {
+		return 0, ErrFileTooLarge
+	}
+	// read one more byte to know whether it's exceeded.
+	if int64(len(p)) > r.remain+1 {
+		p = p[:r.remain+1]
+	}
+	n, err := r.ReadCloser.Read(p)
+	r.remain -= int64(n)
+	if r.remain < 0 {
+		return n, ErrFileTooLarge
+	}
+	return n, err
+}
+
+type cancelReadCloser struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (r *cancelReadCloser) Close() error {
+	defer r.cancel()
+	return r.ReadCloser.Close()
+}
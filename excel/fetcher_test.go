@@ -0,0 +1,145 @@
+package excel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOpenFromUri(t *testing.T) {
+	path, err := filepath.Abs(TestFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	uris := []string{
+		TestFilePath,
+		"file://" + filepath.ToSlash(path),
+		"mem://simple.xlsx",
+	}
+	for _, uri := range uris {
+		t.Run(uri, func(t *testing.T) {
+			conn := NewConnector(WithFetcher("mem", FetcherFunc(func(ctx context.Context, u *url.URL) (io.ReadCloser, error) {
+				return os.Open(TestFilePath)
+			})))
+			if err := conn.OpenFromUri(uri); err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+			var list []Standard
+			rd := conn.MustReader(list)
+			defer rd.Close()
+			if err := rd.ReadAll(&list); err != nil {
+				t.Fatal(err)
+			}
+			if len(list) != len(expectStandardList) {
+				t.Errorf("unexpect list: \n%s", MustJsonPrettyString(list))
+			}
+		})
+	}
+
+	conn := NewConnector()
+	if err = conn.OpenFromUri("unknown://simple.xlsx"); !errors.Is(err, ErrFetcherNotExist) {
+		t.Errorf("expect ErrFetcherNotExist but got: %+v", err)
+	}
+	RegisterFetcher("unknown", FetcherFunc(func(ctx context.Context, u *url.URL) (io.ReadCloser, error) {
+		return os.Open(TestFilePath)
+	}))
+	defer RegisterFetcher("unknown", nil)
+	if err = conn.OpenFromUri("unknown://simple.xlsx"); err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+}
+
+func TestFileURIPath(t *testing.T) {
+	tests := []struct {
+		uri  string
+		want string
+	}{
+		{uri: "file:///tmp/a.xlsx", want: filepath.FromSlash("/tmp/a.xlsx")},
+		{uri: "file://localhost/tmp/a%20b.xlsx", want: filepath.FromSlash("/tmp/a b.xlsx")},
+		{uri: "file:///C:/dir/a.xlsx", want: filepath.FromSlash("C:/dir/a.xlsx")},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(tt.uri)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := fileURIPath(u); err != nil || got != tt.want {
+			t.Errorf("fileURIPath(%s) = %q, %v, want %q", tt.uri, got, err, tt.want)
+		}
+	}
+	u, _ := url.Parse("file://remote/tmp/a.xlsx")
+	if _, err := fileURIPath(u); err == nil {
+		t.Errorf("expect error of remote host")
+	}
+
+	path, err := filepath.Abs(TestFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	conn := NewConnector()
+	if err = conn.OpenFromUriContext(ctx, "file://"+filepath.ToSlash(path)); !errors.Is(err, context.Canceled) {
+		t.Errorf("expect context.Canceled but got: %+v", err)
+	}
+	if _, err = (fileFetcher{}).Fetch(ctx, &url.URL{Scheme: "file", Path: path}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expect context.Canceled but got: %+v", err)
+	}
+}
+
+func TestHTTPFetcher(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir("./testdata")))
+	defer server.Close()
+
+	info, err := os.Stat(TestFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn := NewConnector(WithFetcher("http", &HTTPFetcher{Client: server.Client(), MaxSize: info.Size() - 1}))
+	if err = conn.OpenFromUri(server.URL + "/simple.xlsx"); err != ErrFileTooLarge {
+		t.Errorf("expect ErrFileTooLarge but got: %+v", err)
+	}
+	if err = conn.OpenFromUri(server.URL + "/not_exist.xlsx"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expect error of 404 but got: %+v", err)
+	}
+
+	// the body without Content-Length is limited when reading.
+	fetcher := &HTTPFetcher{MaxSize: 10}
+	chunked := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(make([]byte, 8))
+		w.(http.Flusher).Flush()
+		w.Write(make([]byte, 8))
+	}))
+	defer chunked.Close()
+	u, _ := url.Parse(chunked.URL)
+	rc, err := fetcher.Fetch(context.Background(), u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	if _, err = io.ReadAll(rc); err != ErrFileTooLarge {
+		t.Errorf("expect ErrFileTooLarge but got: %+v", err)
+	}
+
+	// the built-in fetcher is limited by default.
+	if f, ok := getFetcher("https"); !ok || f.(*HTTPFetcher).Timeout != DefaultFetchTimeout || f.(*HTTPFetcher).MaxSize != DefaultFetchMaxSize {
+		t.Errorf("unexpect built-in fetcher: %+v", f)
+	}
+
+	// the fetching is canceled by ctx.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	conn = NewConnector(WithFetcher("http", &HTTPFetcher{Client: server.Client()}))
+	if err = conn.OpenFromUriContext(ctx, server.URL+"/simple.xlsx"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expect context.Canceled but got: %+v", err)
+	}
+}
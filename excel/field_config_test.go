@@ -39,6 +39,7 @@ func (StandardFieldConfig) GetXLSXFieldConfigs() map[string]excel.FieldConfig {
 
 var expectStandardFieldConfigList = []StandardFieldConfig{
 	{
+		Time:    excel.StdTime,
 		ID:      1,
 		Name:    "Andy",
 		NamePtr: excel.StrPtr("Andy"),
@@ -49,6 +50,7 @@ var expectStandardFieldConfigList = []StandardFieldConfig{
 		},
 	},
 	{
+		Time:    excel.StdTime,
 		ID:      2,
 		Name:    "Leo",
 		NamePtr: excel.StrPtr("Leo"),
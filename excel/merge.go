@@ -0,0 +1,165 @@
+package excel
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// worksheet表里的合并单元格字段
+	_MergeCells = "mergeCells"
+)
+
+type xlsxMergeCells struct {
+	MergeCell []xlsxMergeCell `xml:"mergeCell"`
+}
+
+type xlsxMergeCell struct {
+	Ref string `xml:"ref,attr"`
+}
+
+// mergeCell is a merged region of worksheet, such as "A1:C2".
+type mergeCell struct {
+	firstColumn int
+	firstRow    int
+	lastColumn  int
+	lastRow     int
+	// the top-left cell, nil if it's empty or not read.
+	cell *rowCell
+}
+
+// parseMergeCell parse the ref of merged region, such as "A1:C2".
+func parseMergeCell(ref string) (*mergeCell, error) {
+	first, last := ref, ref
+	if i := strings.IndexByte(ref, ':'); i >= 0 {
+		first, last = ref[:i], ref[i+1:]
+	}
+	m := &mergeCell{}
+	var err error
+	if m.firstColumn, m.firstRow, err = parseCellRef(first); err != nil {
+		return nil, fmt.Errorf("invalid merge cell %s: %s", ref, err)
+	}
+	if m.lastColumn, m.lastRow, err = parseCellRef(last); err != nil {
+		return nil, fmt.Errorf("invalid merge cell %s: %s", ref, err)
+	}
+	if m.firstColumn > m.lastColumn {
+		m.firstColumn, m.lastColumn = m.lastColumn, m.firstColumn
+	}
+	if m.firstRow > m.lastRow {
+		m.firstRow, m.lastRow = m.lastRow, m.firstRow
+	}
+	return m, nil
+}
+
+// readMergeCells scan the mergeCells of worksheet, it's after the sheetData,
+// so the worksheet has to be read once more before reading rows.
+func readMergeCells(rc io.Reader) ([]*mergeCell, error) {
+	decoder := xml.NewDecoder(rc)
+	var merges []*mergeCell
+	for t, err := decoder.Token(); err == nil; t, err = decoder.Token() {
+		token, ok := t.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch token.Name.Local {
+		case _SheetData:
+			if err = decoder.Skip(); err != nil {
+				return nil, err
+			}
+		case _MergeCells:
+			xmc := &xlsxMergeCells{}
+			if err = decoder.DecodeElement(xmc, &token); err != nil {
+				return nil, err
+			}
+			for _, c := range xmc.MergeCell {
+				m, err := parseMergeCell(c.Ref)
+				if err != nil {
+					return nil, err
+				}
+				merges = append(merges, m)
+			}
+		}
+	}
+	return merges, nil
+}
+
+// mergedCells fill the value of merged regions into rows, the rows should be read in order.
+type mergedCells struct {
+	// regions not reached yet, sorted by first row.
+	pending []*mergeCell
+	// regions cover current row.
+	active []*mergeCell
+}
+
+func newMergedCells(merges []*mergeCell) *mergedCells {
+	sort.SliceStable(merges, func(i, j int) bool {
+		return merges[i].firstRow < merges[j].firstRow
+	})
+	return &mergedCells{pending: merges}
+}
+
+// fill the top-left value into every cell covered by the regions in row,
+// return the cells sorted by column.
+func (mc *mergedCells) fill(row int, cells []*rowCell) []*rowCell {
+	active := mc.active[:0]
+	for _, m := range mc.active {
+		if m.lastRow >= row {
+			active = append(active, m)
+		}
+	}
+	for len(mc.pending) > 0 && mc.pending[0].firstRow <= row {
+		if m := mc.pending[0]; m.lastRow >= row {
+			active = append(active, m)
+		}
+		mc.pending = mc.pending[1:]
+	}
+	mc.active = active
+	if len(active) == 0 {
+		return cells
+	}
+
+	// map[columnIndex]index of cells
+	columns := make(map[int]int, len(cells))
+	for i, c := range cells {
+		columns[c.columnIndex] = i
+	}
+	added := false
+	for _, m := range active {
+		if m.firstRow == row {
+			if i, ok := columns[m.firstColumn]; ok {
+				topLeft := *cells[i]
+				m.cell = &topLeft
+			}
+		}
+		if m.cell == nil {
+			continue
+		}
+		for column := m.firstColumn; column <= m.lastColumn; column++ {
+			if row == m.firstRow && column == m.firstColumn {
+				continue
+			}
+			c := *m.cell
+			c.columnIndex = column
+			c.Ref = ToColumnName(column) + strconv.Itoa(row)
+			// the covered cell is not calculated by formula.
+			c.Formula = ""
+			if i, ok := columns[column]; ok {
+				cells[i] = &c
+				continue
+			}
+			columns[column] = len(cells)
+			cells = append(cells, &c)
+			added = true
+		}
+	}
+	if added {
+		sort.Slice(cells, func(i, j int) bool {
+			return cells[i].columnIndex < cells[j].columnIndex
+		})
+	}
+	return cells
+}
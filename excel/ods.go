@@ -0,0 +1,518 @@
+package excel
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ods的mimetype文件内容
+	_ODSMimeType     = "application/vnd.oasis.opendocument.spreadsheet"
+	_ODSMimeTypePath = "mimetype"
+	_ODSContentPath  = "content.xml"
+
+	// content.xml中的命名空间
+	_ODSTableNS   = "urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	_ODSOfficeNS  = "urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+	_ODSTextNS    = "urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+	_ODSCalcextNS = "urn:org:documentfoundation:names:experimental:calc:xmlns:calcext:1.0"
+	_ODSStyleNS   = "urn:oasis:names:tc:opendocument:xmlns:style:1.0"
+
+	// 行列数的上限，同xlsx，避免重复的行列展开过多
+	_ODSMaxRows    = 1 << 20
+	_ODSMaxColumns = 1 << 14
+	// 展开重复的行列后非空单元格（含合并单元格）总数的上限，避免很小的文件占用大量内存
+	_ODSMaxCells = 1 << 21
+)
+
+// isODS report whether the zip is an OpenDocument Spreadsheet, return its content.xml.
+func isODS(r *zip.Reader) (*zip.File, bool) {
+	var mimetype, content, workbook *zip.File
+	for _, f := range r.File {
+		switch f.Name {
+		case _ODSMimeTypePath:
+			mimetype = f
+		case _ODSContentPath:
+			content = f
+		case _WorkBookPath:
+			workbook = f
+		}
+	}
+	if content == nil || workbook != nil {
+		return nil, false
+	}
+	if mimetype == nil {
+		// the mimetype is optional
+		return content, true
+	}
+	rc, err := mimetype.Open()
+	if err != nil {
+		return nil, false
+	}
+	defer rc.Close()
+	b, err := io.ReadAll(io.LimitReader(rc, 256))
+	if err != nil {
+		return nil, false
+	}
+	return content, strings.TrimSpace(string(b)) == _ODSMimeType
+}
+
+// odsBook is the workbook of ods, the files are kept in zip of connect.
+type odsBook struct {
+	content *zip.File
+	names   []string
+	// whether the table is hidden by table:display="false" of its style
+	hidden []bool
+	// the global named ranges and expressions
+	namedRanges []DefinedName
+}
+
+// openODS read the names of tables, the hidden styles and the named ranges in content.xml.
+func openODS(content *zip.File) (*odsBook, error) {
+	rc, err := content.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	book := &odsBook{content: content}
+	// the names of table styles with table:display="false", and the style being read
+	hiddenStyles := make(map[string]bool)
+	style := ""
+	decoder := xml.NewDecoder(rc)
+	for t, err := decoder.Token(); err != io.EOF; t, err = decoder.Token() {
+		if err != nil {
+			return nil, fmt.Errorf("read ods content failed: %w", err)
+		}
+		token, ok := t.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch {
+		case isODSElement(token.Name, _ODSStyleNS, "style"):
+			style = ""
+			if odsAttr(&token, _ODSStyleNS, "family") == "table" {
+				style = odsAttr(&token, _ODSStyleNS, "name")
+			}
+		case isODSElement(token.Name, _ODSStyleNS, "table-properties"):
+			if style != "" && odsAttr(&token, _ODSTableNS, "display") == "false" {
+				hiddenStyles[style] = true
+			}
+		case isODSElement(token.Name, _ODSTableNS, "table"):
+			book.names = append(book.names, odsAttr(&token, _ODSTableNS, "name"))
+			book.hidden = append(book.hidden, hiddenStyles[odsAttr(&token, _ODSTableNS, "style-name")])
+			if err = decoder.Skip(); err != nil {
+				return nil, fmt.Errorf("read ods content failed: %w", err)
+			}
+		case isODSElement(token.Name, _ODSTableNS, "named-range"):
+			book.namedRanges = append(book.namedRanges, DefinedName{
+				Name:     odsAttr(&token, _ODSTableNS, "name"),
+				RefersTo: odsRangeAddress(odsAttr(&token, _ODSTableNS, "cell-range-address")),
+			})
+		case isODSElement(token.Name, _ODSTableNS, "named-expression"):
+			book.namedRanges = append(book.namedRanges, DefinedName{
+				Name:     odsAttr(&token, _ODSTableNS, "name"),
+				RefersTo: strings.TrimPrefix(odsAttr(&token, _ODSTableNS, "expression"), "of:"),
+			})
+		}
+	}
+	if len(book.names) == 0 {
+		return nil, ErrWorkbookNotExist
+	}
+	return book, nil
+}
+
+func (book *odsBook) sheetNames() []string {
+	names := make([]string, len(book.names))
+	copy(names, book.names)
+	return names
+}
+
+func (book *odsBook) sheetNameOf(i int) string {
+	if i < 1 || i > len(book.names) {
+		return ""
+	}
+	return book.names[i-1]
+}
+
+// sheetInfos return the metadata of tables, the dimension is unknown before reading the table.
+func (book *odsBook) sheetInfos() ([]SheetInfo, error) {
+	infos := make([]SheetInfo, len(book.names))
+	for i, name := range book.names {
+		infos[i] = SheetInfo{Name: name, Index: i + 1, Visibility: SheetVisible}
+		if book.hidden[i] {
+			infos[i].Visibility = SheetHidden
+		}
+	}
+	return infos, nil
+}
+
+// is1904 return false, the null date of ods is 1899-12-30 like the 1900 date system.
+func (book *odsBook) is1904() bool {
+	return false
+}
+
+// definedNames return the global named ranges and expressions of ods.
+func (book *odsBook) definedNames() []DefinedName {
+	names := make([]DefinedName, len(book.namedRanges))
+	copy(names, book.namedRanges)
+	return names
+}
+
+func (book *odsBook) close() error {
+	return nil
+}
+
+func (book *odsBook) openSheet(sheet string, config *Config) (rowSource, []*mergeCell, error) {
+	rc, err := book.content.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rc.Close()
+	location := config.Location
+	if location == nil {
+		location = time.UTC
+	}
+	decoder := xml.NewDecoder(rc)
+	for t, err := decoder.Token(); err != io.EOF; t, err = decoder.Token() {
+		if err != nil {
+			return nil, nil, fmt.Errorf("read ods sheet %s failed: %w", sheet, err)
+		}
+		token, ok := t.(xml.StartElement)
+		if !ok || !isODSElement(token.Name, _ODSTableNS, "table") {
+			continue
+		}
+		if odsAttr(&token, _ODSTableNS, "name") != sheet {
+			if err = decoder.Skip(); err != nil {
+				return nil, nil, fmt.Errorf("read ods sheet %s failed: %w", sheet, err)
+			}
+			continue
+		}
+		table := &odsTable{decoder: decoder, location: location}
+		if err = table.read(); err != nil {
+			return nil, nil, fmt.Errorf("read ods sheet %s failed: %w", sheet, err)
+		}
+		rows := &memoryRows{rows: table.rows, size: int64(book.content.UncompressedSize64)}
+		return rows, table.merges, nil
+	}
+	return nil, nil, ErrSheetNotExist
+}
+
+// odsTable read the rows of a table:table element.
+type odsTable struct {
+	decoder  *xml.Decoder
+	location *time.Location
+	rows     []*memoryRow
+	merges   []*mergeCell
+	// number of next row, starts from 1
+	row int
+	// number of the non-empty cells and merged cells expanded
+	cells int
+}
+
+// read the rows until the end of table, the rows may be grouped by table:table-row-group and so on.
+func (table *odsTable) read() error {
+	table.row = 1
+	for {
+		t, err := table.decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch token := t.(type) {
+		case xml.StartElement:
+			switch {
+			case isODSElement(token.Name, _ODSTableNS, "table-row"):
+				if err = table.readRow(&token); err != nil {
+					return err
+				}
+			case isODSElement(token.Name, _ODSTableNS, "table-column"),
+				isODSElement(token.Name, _ODSTableNS, "table-columns"),
+				isODSElement(token.Name, _ODSTableNS, "table-header-columns"),
+				isODSElement(token.Name, _ODSTableNS, "table-column-group"),
+				isODSElement(token.Name, _ODSOfficeNS, "forms"),
+				isODSElement(token.Name, _ODSTableNS, "shapes"):
+				if err = table.decoder.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			if isODSElement(token.Name, _ODSTableNS, "table") {
+				return nil
+			}
+		}
+		if table.row > _ODSMaxRows {
+			// the rest are the repeated empty rows generally.
+			return table.decoder.Skip()
+		}
+	}
+}
+
+// readRow read the cells of table:table-row, the row is copied if repeated.
+func (table *odsTable) readRow(start *xml.StartElement) error {
+	repeated := odsRepeated(start, "number-rows-repeated")
+	var cells []*rowCell
+	// the merged cells started in the row, firstRow and lastRow are relative to it.
+	var spans []mergeCell
+	column := 0
+	for {
+		t, err := table.decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch token := t.(type) {
+		case xml.StartElement:
+			switch {
+			case isODSElement(token.Name, _ODSTableNS, "table-cell"):
+				cell, err := table.readCell(&token)
+				if err != nil {
+					return err
+				}
+				n := odsRepeated(&token, "number-columns-repeated")
+				if cell.Value != "" || cell.Formula != "" {
+					rows := odsRepeated(&token, "number-rows-spanned")
+					columns := odsRepeated(&token, "number-columns-spanned")
+					for i := 0; i < n && column+i < _ODSMaxColumns; i++ {
+						cells = append(cells, &rowCell{Cell: cell, columnIndex: column + i})
+						if rows > 1 || columns > 1 {
+							spans = append(spans, mergeCell{
+								firstColumn: column + i,
+								lastColumn:  column + i + columns - 1,
+								lastRow:     rows - 1,
+							})
+						}
+					}
+				}
+				column += n
+			case isODSElement(token.Name, _ODSTableNS, "covered-table-cell"):
+				// the cell covered by merged cell, its content is hidden.
+				column += odsRepeated(&token, "number-columns-repeated")
+				if err = table.decoder.Skip(); err != nil {
+					return err
+				}
+			default:
+				if err = table.decoder.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			if !isODSElement(token.Name, _ODSTableNS, "table-row") {
+				continue
+			}
+			if len(cells) == 0 {
+				// the empty rows are omitted.
+				table.row += repeated
+				return nil
+			}
+			for i := 0; i < repeated && table.row <= _ODSMaxRows; i++ {
+				if table.cells += len(cells) + len(spans); table.cells > _ODSMaxCells {
+					return fmt.Errorf("too many cells expanded from the repeated rows and columns, more than %d", _ODSMaxCells)
+				}
+				row := &memoryRow{number: table.row, cells: make([]*rowCell, len(cells))}
+				for j, cell := range cells {
+					c := *cell
+					c.Ref = ToColumnName(c.columnIndex) + strconv.Itoa(table.row)
+					row.cells[j] = &c
+				}
+				table.rows = append(table.rows, row)
+				for _, span := range spans {
+					merge := span
+					merge.firstRow += table.row
+					merge.lastRow += table.row
+					table.merges = append(table.merges, &merge)
+				}
+				table.row++
+			}
+			return nil
+		}
+	}
+}
+
+// readCell read the value of table:table-cell, the text of office:annotation is ignored.
+func (table *odsTable) readCell(start *xml.StartElement) (cell Cell, err error) {
+	text, err := readODSText(table.decoder)
+	if err != nil {
+		return cell, err
+	}
+	if formula := odsAttr(start, _ODSTableNS, "formula"); formula != "" {
+		// such as "of:=SUM([.A1:.A2])"
+		if i := strings.Index(formula, "="); i >= 0 {
+			formula = formula[i+1:]
+		}
+		cell.Formula = formula
+	}
+	cell.Type = CellTypeString
+	cell.Value = text
+	if odsAttr(start, _ODSCalcextNS, "value-type") == "error" {
+		cell.Type = CellTypeError
+		return cell, nil
+	}
+	switch odsAttr(start, _ODSOfficeNS, "value-type") {
+	case "float", "percentage", "currency":
+		cell.Type = CellTypeNumber
+		cell.Value = odsAttr(start, _ODSOfficeNS, "value")
+	case "date":
+		value := odsAttr(start, _ODSOfficeNS, "date-value")
+		t, err := parseODSDate(value, table.location)
+		if err != nil {
+			return cell, fmt.Errorf("bad date value %q: %w", value, err)
+		}
+		cell.Type = CellTypeDate
+		cell.Value = t.Format(time.RFC3339Nano)
+	case "time":
+		value := odsAttr(start, _ODSOfficeNS, "time-value")
+		d, err := parseODSDuration(value)
+		if err != nil {
+			return cell, fmt.Errorf("bad time value %q: %w", value, err)
+		}
+		// the time is a day fraction of 1899-12-30 as excel
+		cell.Type = CellTypeDate
+		cell.Value = time.Date(1899, 12, 30, 0, 0, 0, 0, table.location).Add(d).Format(time.RFC3339Nano)
+	case "boolean":
+		cell.Type = CellTypeBool
+		cell.Value = "0"
+		if odsAttr(start, _ODSOfficeNS, "boolean-value") == "true" {
+			cell.Value = "1"
+		}
+	case "string":
+		if s := odsAttr(start, _ODSOfficeNS, "string-value"); s != "" {
+			cell.Value = s
+		}
+	}
+	return cell, nil
+}
+
+// readODSText read the paragraphs until the end of current element, they are joined by "\n".
+func readODSText(decoder *xml.Decoder) (string, error) {
+	var b strings.Builder
+	paragraphs := 0
+	for depth := 0; ; {
+		t, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+		switch token := t.(type) {
+		case xml.StartElement:
+			switch {
+			case isODSElement(token.Name, _ODSOfficeNS, "annotation"):
+				if err = decoder.Skip(); err != nil {
+					return "", err
+				}
+				continue
+			case isODSElement(token.Name, _ODSTextNS, "p"), isODSElement(token.Name, _ODSTextNS, "h"):
+				if paragraphs > 0 {
+					b.WriteByte('\n')
+				}
+				paragraphs++
+			case isODSElement(token.Name, _ODSTextNS, "s"):
+				n := 1
+				if c := odsAttr(&token, _ODSTextNS, "c"); c != "" {
+					if n, err = strconv.Atoi(c); err != nil {
+						return "", fmt.Errorf("bad count of spaces %q: %w", c, err)
+					}
+				}
+				b.WriteString(strings.Repeat(" ", n))
+			case isODSElement(token.Name, _ODSTextNS, "tab"):
+				b.WriteByte('\t')
+			case isODSElement(token.Name, _ODSTextNS, "line-break"):
+				b.WriteByte('\n')
+			}
+			depth++
+		case xml.CharData:
+			if depth > 0 {
+				b.Write(token)
+			}
+		case xml.EndElement:
+			if depth == 0 {
+				return b.String(), nil
+			}
+			depth--
+		}
+	}
+}
+
+// parseODSDate parse the date-value such as "2022-10-11" or "2022-10-11T12:00:29", it's the wall clock in loc.
+func parseODSDate(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t.In(loc), nil
+	}
+	layout := "2006-01-02"
+	if strings.Contains(value, "T") {
+		layout = "2006-01-02T15:04:05.999999999"
+	}
+	return time.ParseInLocation(layout, value, loc)
+}
+
+// parseODSDuration parse the time-value of ISO 8601 duration such as "PT12H30M15S".
+func parseODSDuration(value string) (time.Duration, error) {
+	s := strings.TrimPrefix(value, "P")
+	if len(s) == len(value) {
+		return 0, fmt.Errorf("not a duration")
+	}
+	var d time.Duration
+	if i := strings.Index(s, "D"); i >= 0 {
+		days, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return 0, err
+		}
+		d = time.Duration(days) * 24 * time.Hour
+		s = s[i+1:]
+	}
+	s = strings.TrimPrefix(s, "T")
+	if s == "" {
+		return d, nil
+	}
+	clock, err := time.ParseDuration(strings.ToLower(s))
+	if err != nil {
+		return 0, err
+	}
+	return d + clock, nil
+}
+
+// odsRangeAddress convert the range address of ods such as "$Sheet1.$A$1:.$D$10" to "Sheet1!$A$1:$D$10",
+// the address is returned as is if it's not a range of one table.
+func odsRangeAddress(address string) string {
+	sheet, refs := "", make([]string, 0, 2)
+	for _, part := range strings.Split(address, ":") {
+		i := strings.LastIndexByte(part, '.')
+		if i < 0 {
+			return address
+		}
+		if table := strings.TrimPrefix(part[:i], "$"); table != "" {
+			if sheet != "" && sheet != table {
+				return address
+			}
+			sheet = table
+		}
+		refs = append(refs, part[i+1:])
+	}
+	if sheet == "" || len(refs) > 2 {
+		return address
+	}
+	return sheet + "!" + strings.Join(refs, ":")
+}
+
+func isODSElement(name xml.Name, space, local string) bool {
+	return name.Local == local && name.Space == space
+}
+
+func odsAttr(token *xml.StartElement, space, local string) string {
+	for _, a := range token.Attr {
+		if a.Name.Local == local && a.Name.Space == space {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// odsRepeated return the count of table attribute such as number-columns-repeated, default is 1.
+func odsRepeated(token *xml.StartElement, local string) int {
+	n, err := strconv.Atoi(odsAttr(token, _ODSTableNS, local))
+	if err != nil || n < 1 {
+		return 1
+	}
+	return n
+}
@@ -0,0 +1,159 @@
+package excel
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const odsContent = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+	xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+	xmlns:calcext="urn:org:documentfoundation:names:experimental:calc:xmlns:calcext:1.0">
+<office:body><office:spreadsheet>
+<table:table table:name="ODSRow">
+	<table:table-column table:number-columns-repeated="1024"/>
+	<table:table-header-rows>
+	<table:table-row>
+		<table:table-cell office:value-type="string"><text:p>ID</text:p></table:table-cell>
+		<table:table-cell office:value-type="string"><text:p>Name</text:p></table:table-cell>
+		<table:table-cell office:value-type="string"><text:p>Birthday</text:p></table:table-cell>
+		<table:table-cell office:value-type="string"><text:p>Active</text:p></table:table-cell>
+		<table:table-cell office:value-type="string"><text:p>Total</text:p></table:table-cell>
+		<table:table-cell office:value-type="string"><text:p>Alarm</text:p></table:table-cell>
+		<table:table-cell table:number-columns-repeated="1018"/>
+	</table:table-row>
+	</table:table-header-rows>
+	<table:table-row>
+		<table:table-cell office:value-type="float" office:value="1"><text:p>1</text:p></table:table-cell>
+		<table:table-cell office:value-type="string"><text:p>Andy<text:s text:c="2"/>Lee</text:p><text:p>second line</text:p><office:annotation><text:p>comment</text:p></office:annotation></table:table-cell>
+		<table:table-cell office:value-type="date" office:date-value="2000-01-01T12:00:00"><text:p>01/01/00</text:p></table:table-cell>
+		<table:table-cell office:value-type="boolean" office:boolean-value="true"><text:p>TRUE</text:p></table:table-cell>
+		<table:table-cell table:formula="of:=[.A2]*100" office:value-type="percentage" office:value="100"><text:p>100</text:p></table:table-cell>
+		<table:table-cell office:value-type="time" office:time-value="PT12H30M00S"/>
+	</table:table-row>
+	<table:table-row table:number-rows-repeated="2">
+		<table:table-cell office:value-type="float" office:value="2"/>
+		<table:table-cell table:number-columns-spanned="2" table:number-rows-spanned="1" office:value-type="string"><text:p>Leo</text:p></table:table-cell>
+		<table:covered-table-cell/>
+		<table:table-cell office:value-type="boolean" office:boolean-value="false"/>
+		<table:table-cell table:formula="of:=1/0" office:value-type="float" office:value="0" calcext:value-type="error"><text:p>#DIV/0!</text:p></table:table-cell>
+	</table:table-row>
+	<table:table-row table:number-rows-repeated="1048570"><table:table-cell table:number-columns-repeated="1024"/></table:table-row>
+</table:table>
+<table:table table:name="Empty"/>
+</office:spreadsheet></office:body>
+</office:document-content>`
+
+func testODS(t *testing.T) []byte {
+	return testODSContent(t, odsContent)
+}
+
+// testODSContent build an ods binary with the content.xml.
+func testODSContent(t *testing.T, content string) []byte {
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for _, file := range [][2]string{{_ODSMimeTypePath, _ODSMimeType}, {_ODSContentPath, content}} {
+		w, err := zw.Create(file[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write([]byte(file[1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+type ODSRow struct {
+	ID       int
+	Name     string
+	Birthday time.Time
+	Active   bool
+}
+
+func TestReadODS(t *testing.T) {
+	conn := NewConnector()
+	if err := conn.OpenBinary(testODS(t)); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if names := conn.GetSheetNames(); !reflect.DeepEqual(names, []string{"ODSRow", "Empty"}) {
+		t.Errorf("unexpect sheet names: %v", names)
+	}
+
+	var list []ODSRow
+	rd, err := conn.NewReader(&list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	if err = rd.ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	expectList := []ODSRow{
+		{ID: 1, Name: "Andy  Lee\nsecond line", Birthday: time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC), Active: true},
+		{ID: 2, Name: "Leo"},
+		{ID: 2, Name: "Leo"},
+	}
+	if !reflect.DeepEqual(list, expectList) {
+		t.Errorf("unexpect list: \n%s", MustJsonPrettyString(list))
+	}
+
+	rd, err = conn.NewReaderByConfig(&Config{Sheet: 1, FillMergedCells: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	var cells []map[string]Cell
+	if err = rd.ReadAll(&cells); err != nil {
+		t.Fatal(err)
+	}
+	if len(cells) != 3 {
+		t.Fatalf("unexpect cells: \n%s", MustJsonPrettyString(cells))
+	}
+	if c := cells[0]["Total"]; c.Type != CellTypeNumber || c.Value != "100" || c.Formula != "[.A2]*100" || c.Ref != "E2" {
+		t.Errorf("unexpect formula cell: %+v", c)
+	}
+	if c := cells[0]["Alarm"]; c.Type != CellTypeDate || c.Value != "1899-12-30T12:30:00Z" {
+		t.Errorf("unexpect time cell: %+v", c)
+	}
+	if c := cells[2]["Birthday"]; c.Value != "Leo" || c.Ref != "C4" {
+		t.Errorf("unexpect merged cell: %+v", c)
+	}
+	if c := cells[2]["Total"]; c.Type != CellTypeError || c.Value != "#DIV/0!" {
+		t.Errorf("unexpect error cell: %+v", c)
+	}
+
+	if err = conn.MustReader("Empty").ReadAll(&[]map[string]string{}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReadODSTooManyCells(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+	xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0">
+<office:body><office:spreadsheet>
+<table:table table:name="Bomb">
+	<table:table-row table:number-rows-repeated="20000"><table:table-cell table:number-columns-repeated="16384"><text:p>x</text:p></table:table-cell></table:table-row>
+</table:table>
+</office:spreadsheet></office:body>
+</office:document-content>`
+	conn := NewConnector()
+	if err := conn.OpenBinary(testODSContent(t, content)); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := conn.NewReader("Bomb"); err == nil {
+		t.Error("expect error of too many cells")
+	}
+}
@@ -1,10 +1,11 @@
 package excel
 
 import (
-	"encoding/xml"
+	"context"
 	"fmt"
 	"io"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -15,34 +16,158 @@ const (
 	_RowPrefix = "row"
 
 	_AllNumber = "0123456789"
+
+	// 单元格类型：内联字符串
+	_InlineStr = "inlineStr"
+	// 单元格类型：数字
+	_N = "n"
 )
 
 // read is default implement of reader
 type read struct {
-	connecter          *connect
-	decoder            *xml.Decoder
-	decoderReadCloseer io.ReadCloser
-	title              *titleRow
-	schameMap          map[reflect.Type]*schema
+	// rows of sheet
+	source    rowSource
+	title     *titleRow
+	schameMap map[reflect.Type]*schema
+
+	// name of sheet
+	sheet string
+	// the date system and location to convert the cells to time.Time
+	times timeContext
+	// collect all bad cells instead of stopping at the first one
+	collectErrors bool
+	// fill merged cells into rows, nil if not required
+	mergedCells *mergedCells
+
+	// report the progress after every row read, may be nil
+	progress func(Progress)
+	// number of rows read
+	rowsRead int
+	// values of the unique fields read before
+	uniques uniqueValues
+	// the cells out of it are ignored, nil if the whole sheet is read
+	bounds *cellRange
+	// how to handle the empty rows, it's set after the title and skipped rows are read
+	emptyRowPolicy EmptyRowPolicy
+	// the gap of row numbers is the rows omitted by file, false if the source is linedRows
+	gapOmitted bool
+	// number of current row, it's behind the source at the rows omitted by file
+	row int
+	// the current row is omitted by file, the source is at a row after it
+	omitted bool
+	// stopped at an empty row by EmptyRowStop
+	stopped bool
 }
 
 // Move the cursor to next row's start.
 func (rd *read) Next() bool {
-	for t, err := rd.decoder.Token(); err == nil; t, err = rd.decoder.Token() {
-		switch token := t.(type) {
-		case xml.StartElement:
-			switch token.Name.Local {
-			case _RowPrefix:
-				return true
+	if rd.stopped {
+		return false
+	}
+	if rd.omitted {
+		// move to the next row omitted by file, or the row of source.
+		rd.row++
+		rd.omitted = rd.row < rd.source.rowNumber()
+		return rd.bounds == nil || rd.bounds.containsRow(rd.row)
+	}
+	for rd.source.next() {
+		row := rd.source.rowNumber()
+		if rd.bounds != nil && row < rd.bounds.firstRow {
+			continue
+		}
+		if row > rd.row+1 && rd.gapOmitted && rd.emptyRowPolicy != EmptyRowSkip {
+			// the rows before are omitted by file.
+			if rd.emptyRowPolicy == EmptyRowStop {
+				rd.stopped = true
+				return false
 			}
+			rd.row++
+			rd.omitted = true
+		} else {
+			rd.row = row
 		}
+		return rd.bounds == nil || rd.bounds.containsRow(rd.row)
 	}
 	return false
 }
 
+// readRow read the cells of current row, the merged cells are filled if required.
+// It's empty at the row omitted by file, and io.EOF at the empty row by EmptyRowStop.
+func (rd *read) readRow() ([]*rowCell, error) {
+	if rd.stopped {
+		return nil, io.EOF
+	}
+	if rd.omitted {
+		return nil, nil
+	}
+	cells, err := rd.source.readRow()
+	if err != nil {
+		return nil, err
+	}
+	rd.row = rd.source.rowNumber()
+	if rd.mergedCells != nil {
+		cells = rd.mergedCells.fill(rd.row, cells)
+	}
+	if rd.bounds != nil {
+		if !rd.bounds.containsRow(rd.row) {
+			return nil, io.EOF
+		}
+		cells = rd.bounds.filter(cells)
+	}
+	if len(cells) == 0 && rd.emptyRowPolicy == EmptyRowStop {
+		rd.stopped = true
+		return nil, io.EOF
+	}
+	return cells, nil
+}
+
+// rowRead count the row read with err and report the progress.
+func (rd *read) rowRead(err error) {
+	if _, ok := err.(CellErrors); err != nil && !ok {
+		return
+	}
+	rd.rowsRead++
+	if rd.progress != nil {
+		consumed, total := rd.source.progress()
+		rd.progress(Progress{
+			Rows:       rd.rowsRead,
+			Bytes:      consumed,
+			TotalBytes: total,
+		})
+	}
+}
+
+// cellError make a *CellError at columnIndex of current row.
+func (rd *read) cellError(columnIndex int, field *fieldConfig, value string, err error) *CellError {
+	cellErr := &CellError{
+		Sheet:  rd.sheet,
+		Row:    rd.row,
+		Column: ToColumnName(columnIndex),
+		Title:  rd.title.titleOf(columnIndex),
+		Value:  value,
+		Err:    err,
+	}
+	if field != nil {
+		cellErr.Field = field.FieldName
+	}
+	return cellErr
+}
+
+// failer return the func to report a bad cell of current row,
+// it returns the error at once, or collects it into errs and goes on in collect-all mode.
+func (rd *read) failer(errs *CellErrors) func(*CellError) error {
+	return func(cellErr *CellError) error {
+		if !rd.collectErrors {
+			return cellErr
+		}
+		*errs = append(*errs, cellErr)
+		return nil
+	}
+}
+
 // Read current row into an object by its pointer
 // return: the last row might be a row with not data,
-//         in rd case will return io.EOF
+// in rd case will return io.EOF
 func (rd *read) Read(i interface{}) error {
 	t := reflect.TypeOf(i)
 	switch t.Kind() {
@@ -71,21 +196,24 @@ func (rd *read) Read(i interface{}) error {
 }
 
 func (rd *read) Close() error {
-	if rd.decoder != nil {
-		rd.decoder = nil
-	}
-	if rd.decoderReadCloseer != nil {
-		rd.decoderReadCloseer.Close()
-		rd.decoderReadCloseer = nil
+	var err error
+	if rd.source != nil {
+		err = rd.source.close()
+		rd.source = nil
 	}
-	rd.connecter = nil
 	rd.title = nil
 	rd.schameMap = nil
-	return nil
+	rd.mergedCells = nil
+	return err
 }
 
 // Read all rows
 func (rd *read) ReadAll(container interface{}) error {
+	return rd.ReadAllContext(context.Background(), container)
+}
+
+// Read all rows until ctx is done, the rows read before are kept in container.
+func (rd *read) ReadAllContext(ctx context.Context, container interface{}) error {
 	val := reflect.ValueOf(container)
 	typ := reflect.Indirect(val).Type()
 
@@ -101,15 +229,25 @@ func (rd *read) ReadAll(container interface{}) error {
 		elemTyp = elemTyp.Elem()
 	}
 	var err error
+	var cellErrs CellErrors
 	switch elemTyp.Kind() {
 	case reflect.Struct:
 		elemSchema := newSchema(elemTyp)
 		slcVal := val.Elem()
 		for rd.Next() {
+			if err = ctx.Err(); err != nil {
+				return err
+			}
 			elmVal := sliceNextElem(slcVal)
 			for err = ErrEmptyRow; err == ErrEmptyRow; {
 				err = rd.readToValue(elemSchema, elmVal)
 			}
+			rd.rowRead(err)
+			if errs, ok := err.(CellErrors); ok {
+				// keep the row and go on in collect-all mode
+				cellErrs = append(cellErrs, errs...)
+				err = nil
+			}
 			if err != nil {
 				// remove the last row.
 				slcVal.SetLen(slcVal.Len() - 1)
@@ -122,10 +260,17 @@ func (rd *read) ReadAll(container interface{}) error {
 	case reflect.Map:
 		slcVal := val.Elem()
 		for rd.Next() {
+			if err = ctx.Err(); err != nil {
+				return err
+			}
 			elmVal := sliceNextElem(slcVal)
 			for err = ErrEmptyRow; err == ErrEmptyRow; {
 				err = rd.readToMap(elemTyp, elmVal)
 			}
+			if errs, ok := err.(CellErrors); ok {
+				cellErrs = append(cellErrs, errs...)
+				err = nil
+			}
 			if err != nil {
 				// remove the last row.
 				slcVal.SetLen(slcVal.Len() - 1)
@@ -139,10 +284,17 @@ func (rd *read) ReadAll(container interface{}) error {
 		reflect.Array:
 		slcVal := val.Elem()
 		for rd.Next() {
+			if err = ctx.Err(); err != nil {
+				return err
+			}
 			elmVal := sliceNextElem(slcVal)
 			for err = ErrEmptyRow; err == ErrEmptyRow; {
 				err = rd.readToSlice(elemTyp, elmVal)
 			}
+			if errs, ok := err.(CellErrors); ok {
+				cellErrs = append(cellErrs, errs...)
+				err = nil
+			}
 			if err != nil {
 				// remove the last row.
 				slcVal.SetLen(slcVal.Len() - 1)
@@ -154,6 +306,9 @@ func (rd *read) ReadAll(container interface{}) error {
 		}
 	}
 
+	if len(cellErrs) > 0 {
+		return cellErrs
+	}
 	return nil
 }
 
@@ -179,6 +334,7 @@ func (rd *read) readToStruct(t reflect.Type, v reflect.Value) error {
 	for err = ErrEmptyRow; err == ErrEmptyRow; {
 		err = rd.readToValue(s, v)
 	}
+	rd.rowRead(err)
 	return err
 }
 
@@ -197,14 +353,14 @@ func (rd *read) readToMap(t reflect.Type, v reflect.Value) error {
 	for err = ErrEmptyRow; err == ErrEmptyRow; {
 		err = rd.readToMapValue(v)
 	}
+	rd.rowRead(err)
 
-	if v.Len() < len(rd.title.dstMap) {
-		for _, keyValue := range v.MapKeys() {
-			title := keyValue.String()
-			if _, ok := rd.title.dstMap[title]; !ok {
-				// fill default value to column not read.
-				val := reflect.New(v.Type().Elem())
-				v.SetMapIndex(reflect.ValueOf(title), val.Elem())
+	if _, ok := err.(CellErrors); (err == nil || ok) && v.Len() < len(rd.title.dstMap) {
+		// fill zero value to column not read, like the fields of struct and the elements of slice.
+		for title := range rd.title.dstMap {
+			key := reflect.ValueOf(title).Convert(v.Type().Key())
+			if title != "" && !v.MapIndex(key).IsValid() {
+				v.SetMapIndex(key, reflect.Zero(v.Type().Elem()))
 			}
 		}
 	}
@@ -222,104 +378,197 @@ func (rd *read) readToSlice(t reflect.Type, v reflect.Value) (err error) {
 	for err = ErrEmptyRow; err == ErrEmptyRow; {
 		err = rd.readToSliceValue(v)
 	}
+	rd.rowRead(err)
 	return
 }
 
+// rowCell is a non-empty cell of current row.
+type rowCell struct {
+	Cell
+	columnIndex int
+	// error of resolving the cell, such as the shared string is out of range.
+	err error
+}
+
 func (rd *read) readToValue(s *schema, v reflect.Value) (err error) {
 	if len(rd.title.dstMap) != len(rd.title.titles) {
 		return ErrDuplicatedTitles
 	}
+	if s.err != nil {
+		return s.err
+	}
 
-	tempCell := &xlsxC{}
 	fieldsMap, err := rd.title.MapToFields(s)
 	if err != nil {
 		return err
 	}
+	cells, err := rd.readRow()
+	if err != nil {
+		return err
+	}
+	if len(cells) == 0 {
+		if rd.emptyRowPolicy != EmptyRowZero {
+			return ErrEmptyRow
+		}
+		v.Set(reflect.Zero(v.Type()))
+		return rd.fillMetas(s, v)
+	}
+
+	// bad cells of current row in collect-all mode
+	var cellErrs CellErrors
+	fail := rd.failer(&cellErrs)
+
 	scaned := false
-	defer func() {
-		if !scaned && err == nil {
-			err = ErrEmptyRow
+	// the columns not mapped of current row
+	var rest reflect.Value
+	if s.rest != nil {
+		if restValue, ok := lookupField(v, s.rest.FieldIndex); ok {
+			restValue.Set(reflect.Zero(restValue.Type()))
 		}
-	}()
-
-	isV := false
-	for t, e := rd.decoder.Token(); e == nil; t, e = rd.decoder.Token() {
-		switch token := t.(type) {
-		case xml.StartElement:
-			switch token.Name.Local {
-			case _C:
-				tempCell.R = ""
-				tempCell.T = ""
-				for _, a := range token.Attr {
-					switch a.Name.Local {
-					case _R:
-						tempCell.R = a.Value
-					case _T:
-						tempCell.T = a.Value
-					}
-				}
-			case _V:
-				isV = true
-			}
-		case xml.EndElement:
-			if token.Name.Local == _RowPrefix {
-				// fill default value to column not read.
-				for _, notFilledFields := range fieldsMap {
-					for _, fieldCnf := range notFilledFields {
-						fieldValue := v.Field(fieldCnf.FieldIndex)
-						// log.Printf("Fill %s = %v with default: %s", v.Type().Field(fieldCnf.FieldIndex).Name, fieldValue.Interface(), fieldCnf.DefaultValue)
-						err = fieldCnf.ScanDefault(fieldValue)
-						if err != nil {
-							return err
-						}
-					}
-				}
-				// 结束当前行
+	}
+	for _, cell := range cells {
+		fields, ok := fieldsMap[cell.columnIndex]
+		if !ok {
+			if s.rest == nil || cell.err != nil {
+				// Not an error, just ignore rd column.
+				continue
+			}
+			if !rest.IsValid() {
+				rest = s.rest.field(v)
+				rest.Set(reflect.MakeMap(rest.Type()))
+			}
+			title := rd.title.titleOf(cell.columnIndex)
+			if title == "" {
+				title = ToColumnName(cell.columnIndex)
+			}
+			rest.SetMapIndex(reflect.ValueOf(title).Convert(rest.Type().Key()), restValue(&cell.Cell, rest.Type().Elem()))
+			scaned = true
+			continue
+		}
+		if cell.err != nil {
+			if err = fail(rd.cellError(cell.columnIndex, nil, cell.Value, cell.err)); err != nil {
 				return err
 			}
-		case xml.CharData:
-			if !isV {
-				break
+			delete(fieldsMap, cell.columnIndex)
+			continue
+		}
+		valStr := cell.Value
+		scaned = true
+		// the blank cell failed to scan will be filled with default.
+		filled := true
+		for _, fieldCnf := range fields {
+			fieldValue := fieldCnf.field(v)
+			if setCell(&cell.Cell, fieldValue) {
+				continue
 			}
-			trimedColumnName := strings.TrimRight(tempCell.R, _AllNumber)
-			columnIndex := ToDecimalism(trimedColumnName)
-			fields, ok := fieldsMap[columnIndex]
-			if !ok {
-				// Not an error, just ignore rd column.
-				break
+			if err = cell.valueError(); err == nil {
+				err = fieldCnf.scan(valStr, fieldValue, rd.times)
 			}
-
-			var valStr string
-			if tempCell.T == _S {
-				tokenInt, err := ToInt(string(token))
-				if err != nil {
+			if err != nil {
+				if len(valStr) == 0 {
+					filled = false
+				} else if err = fail(rd.cellError(cell.columnIndex, fieldCnf, valStr, err)); err != nil {
 					return err
 				}
-				// get string from shared
-				valStr = rd.connecter.getSharedString(tokenInt)
-			} else {
-				valStr = string(token)
+				continue
 			}
-			// println("Key:", trimedColumnName, "Val:", valStr)
-			scaned = true
-			isV = false
-			for _, fieldCnf := range fields {
-				fieldValue := v.Field(fieldCnf.FieldIndex)
-				err = fieldCnf.scan(valStr, fieldValue)
-				if err != nil && len(valStr) > 0 {
+			if err = rd.validate(cell.columnIndex, fieldCnf, valStr, fieldValue, fail); err != nil {
+				return err
+			}
+		}
+		if filled {
+			delete(fieldsMap, cell.columnIndex)
+		}
+	}
+	if !scaned && len(cellErrs) == 0 && rd.emptyRowPolicy == EmptyRowSkip {
+		return ErrEmptyRow
+	}
+	if err = rd.fillMetas(s, v); err != nil {
+		return err
+	}
+
+	// fill default value to column not read.
+	for columnIndex, notFilledFields := range fieldsMap {
+		for _, fieldCnf := range notFilledFields {
+			if _, ok := lookupField(v, fieldCnf.FieldIndex); !ok && fieldCnf.DefaultValue == "" {
+				// keep the nil nested struct if nothing to fill.
+				continue
+			}
+			fieldValue := fieldCnf.field(v)
+			if err = fieldCnf.ScanDefault(fieldValue, rd.times); err != nil {
+				if err = fail(rd.cellError(columnIndex, fieldCnf, fieldCnf.DefaultValue, err)); err != nil {
 					return err
 				}
 			}
-			if err == nil {
-				delete(fieldsMap, columnIndex)
+		}
+	}
+	// the empty cells
+	for columnIndex, notFilledFields := range fieldsMap {
+		for _, fieldCnf := range notFilledFields {
+			if !fieldCnf.NotEmpty {
+				continue
+			}
+			if err = rd.validate(columnIndex, fieldCnf, "", reflect.Value{}, fail); err != nil {
+				return err
 			}
 		}
 	}
+	if len(cellErrs) == 0 && v.CanAddr() {
+		if validator, ok := v.Addr().Interface().(RowValidator); ok {
+			if err = validator.ValidateXLSXRow(); err != nil {
+				for _, cellErr := range rd.rowValidatorErrors(s, cells, err) {
+					if err = fail(cellErr); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	if len(cellErrs) > 0 {
+		return cellErrs
+	}
+	return nil
+}
 
-	if err != nil {
-		return err
+// fillMetas set the fields of row number, sheet name and cell reference of current row.
+func (rd *read) fillMetas(s *schema, v reflect.Value) error {
+	row := rd.row
+	for _, field := range s.metas {
+		fieldValue := field.field(v)
+		switch {
+		case field.RowNum:
+			if fieldValue.CanInt() {
+				fieldValue.SetInt(int64(row))
+			} else {
+				fieldValue.SetUint(uint64(row))
+			}
+		case field.Sheet:
+			fieldValue.SetString(rd.sheet)
+		default:
+			columnIndex, ok, err := rd.title.columnOf(field.cellRefColumn)
+			if err != nil {
+				return err
+			}
+			ref := ""
+			if ok {
+				ref = ToColumnName(columnIndex) + strconv.Itoa(row)
+			}
+			fieldValue.SetString(ref)
+		}
 	}
-	return io.EOF
+	return nil
+}
+
+// validate the field read from the text of cell, the error is passed to fail.
+func (rd *read) validate(columnIndex int, field *fieldConfig, text string, v reflect.Value, fail func(*CellError) error) error {
+	validateErr := field.validate(text, v)
+	if validateErr == nil {
+		validateErr = rd.uniques.check(field, text, rd.row)
+	}
+	if validateErr != nil {
+		return fail(rd.cellError(columnIndex, field, text, validateErr))
+	}
+	return nil
 }
 
 func (rd *read) readToMapValue(v reflect.Value) (err error) {
@@ -327,144 +576,92 @@ func (rd *read) readToMapValue(v reflect.Value) (err error) {
 		return ErrDuplicatedTitles
 	}
 
-	tempCell := &xlsxC{}
-	scaned := false
-	defer func() {
-		if !scaned && err == nil {
-			err = ErrEmptyRow
-		}
-	}()
-	isV := false
-	for t, e := rd.decoder.Token(); e == nil; t, e = rd.decoder.Token() {
-		switch token := t.(type) {
-		case xml.StartElement:
-			switch token.Name.Local {
-			case _C:
-				tempCell.R = ""
-				tempCell.T = ""
-				for _, a := range token.Attr {
-					switch a.Name.Local {
-					case _R:
-						tempCell.R = a.Value
-					case _T:
-						tempCell.T = a.Value
-					}
-				}
-			case _V:
-				isV = true
-			}
-		case xml.EndElement:
-			if token.Name.Local == _RowPrefix {
-				// end of current row
+	cells, err := rd.readRow()
+	if err != nil {
+		return err
+	}
+	if len(cells) == 0 && rd.emptyRowPolicy != EmptyRowZero {
+		return ErrEmptyRow
+	}
+	// bad cells of current row in collect-all mode
+	var cellErrs CellErrors
+	fail := rd.failer(&cellErrs)
+	for _, cell := range cells {
+		if cell.err != nil {
+			if err = fail(rd.cellError(cell.columnIndex, nil, cell.Value, cell.err)); err != nil {
 				return err
 			}
-		case xml.CharData:
-			if !isV {
-				break
-			}
-			trimedColumnName := strings.TrimRight(tempCell.R, _AllNumber)
-			var valStr string
-			if tempCell.T == _S {
-				// get string from shared
-				tokenInt, err := ToInt(string(token))
-				if err != nil {
+			continue
+		}
+		val := reflect.New(v.Type().Elem())
+		if !setCell(&cell.Cell, val.Elem()) {
+			if err = cell.valueError(); err != nil {
+				if err = fail(rd.cellError(cell.columnIndex, nil, cell.Value, err)); err != nil {
 					return err
 				}
-				valStr = rd.connecter.getSharedString(tokenInt)
-			} else {
-				valStr = string(token)
-			}
-			val := reflect.New(v.Type().Elem())
-			_ = scan(valStr, val.Interface())
-			columnIndex := ToDecimalism(trimedColumnName)
-			title := rd.title.srcMap[columnIndex]
-			v.SetMapIndex(reflect.ValueOf(title), val.Elem())
-			// log.Println("Key:", trimedColumnName, "Val:", valStr)
-			scaned = true
-			isV = false
+				continue
+			}
+			_ = scan(cell.Value, val.Interface(), rd.times)
 		}
+		title := rd.title.titleOf(cell.columnIndex)
+		v.SetMapIndex(reflect.ValueOf(title), val.Elem())
 	}
-
-	if err != nil {
-		return err
+	if len(cellErrs) > 0 {
+		return cellErrs
 	}
-	return io.EOF
+	return nil
 }
 
 func (rd *read) readToSliceValue(v reflect.Value) (err error) {
-	tempCell := &xlsxC{}
-	scaned := false
-	defer func() {
-		if !scaned && err == nil {
-			err = ErrEmptyRow
-		}
-	}()
-	isV := false
-	for t, e := rd.decoder.Token(); e == nil; t, e = rd.decoder.Token() {
-		switch token := t.(type) {
-		case xml.StartElement:
-			switch token.Name.Local {
-			case _C:
-				tempCell.R = ""
-				tempCell.T = ""
-				for _, a := range token.Attr {
-					switch a.Name.Local {
-					case _R:
-						tempCell.R = a.Value
-					case _T:
-						tempCell.T = a.Value
-					}
-				}
-			case _V:
-				isV = true
-			}
-		case xml.EndElement:
-			if token.Name.Local == _RowPrefix {
-				// end of current row
+	cells, err := rd.readRow()
+	if err != nil {
+		return err
+	}
+	if len(cells) == 0 && rd.emptyRowPolicy != EmptyRowZero {
+		return ErrEmptyRow
+	}
+	// bad cells of current row in collect-all mode
+	var cellErrs CellErrors
+	fail := rd.failer(&cellErrs)
+	for _, cell := range cells {
+		if cell.err != nil {
+			if err = fail(rd.cellError(cell.columnIndex, nil, cell.Value, cell.err)); err != nil {
 				return err
 			}
-		case xml.CharData:
-			if !isV {
-				break
-			}
-			trimedColumnName := strings.TrimRight(tempCell.R, _AllNumber)
-			var valStr string
-			if tempCell.T == _S {
-				// get string from shared
-				tokenInt, err := ToInt(string(token))
-				if err != nil {
-					return err
-				}
-				valStr = rd.connecter.getSharedString(tokenInt)
-			} else {
-				valStr = string(token)
-			}
-
-			columnIndex := ToDecimalism(trimedColumnName)
-			if columnIndex < v.Len() {
-				val := v.Index(columnIndex)
-				if val.Type().Kind() == reflect.Ptr {
-					val.Set(reflect.New(val.Type().Elem()))
-					_ = scan(valStr, val.Interface())
-				} else if val.CanAddr() {
-					_ = scan(valStr, val.Addr().Interface())
-				} else {
-					return fmt.Errorf("unexpect type of %T, is not ptr and can't addr", v.Interface())
-				}
+			continue
+		}
+		valStr := cell.Value
 
-				// } else {
-				// log.Printf("columnIndex(%d) < v.Len(%d)", columnIndex, v.Len())
+		columnIndex := cell.columnIndex
+		if i := columnIndex - rd.title.firstColumn; i >= v.Len() {
+			if !rd.title.positional || v.Kind() != reflect.Slice {
+				continue
 			}
-			// log.Println("Key:", trimedColumnName, "Val:", valStr)
-			scaned = true
-			isV = false
+			// the length of row is unknown without title row.
+			grown := reflect.MakeSlice(v.Type(), i+1, i+1)
+			reflect.Copy(grown, v)
+			v.Set(grown)
+		}
+		val := v.Index(columnIndex - rd.title.firstColumn)
+		if setCell(&cell.Cell, val) {
+			// metadata of cell
+		} else if err = cell.valueError(); err != nil {
+			if err = fail(rd.cellError(columnIndex, nil, cell.Value, err)); err != nil {
+				return err
+			}
+		} else if val.Type().Kind() == reflect.Ptr {
+			val.Set(reflect.New(val.Type().Elem()))
+			_ = scan(valStr, val.Interface(), rd.times)
+		} else if val.CanAddr() {
+			_ = scan(valStr, val.Addr().Interface(), rd.times)
+		} else {
+			return fmt.Errorf("unexpect type of %T, is not ptr and can't addr", v.Interface())
 		}
 	}
-
-	if err != nil {
-		return err
+	if len(cellErrs) > 0 {
+		return cellErrs
 	}
-	return io.EOF
+	return nil
 }
 
 func (rd *read) getSchame(t reflect.Type) *schema {
@@ -476,20 +673,45 @@ func (rd *read) getSchame(t reflect.Type) *schema {
 	return s
 }
 
-func newReader(cn *connect, workSheetFileReader io.ReadCloser, titleRowIndex, skip int) (Reader, error) {
-	rd, err := newBaseReaderByWorkSheetFile(cn, workSheetFileReader)
-	if err != nil {
-		return nil, err
+// newReader make a reader of rows of sheet, the cursor is moved to the row before first data row.
+func newReader(sheet string, source rowSource, merges []*mergeCell, bounds *cellRange, date1904 bool, config *Config) (Reader, error) {
+	rd := &read{
+		source:        source,
+		sheet:         sheet,
+		times:         timeContext{date1904: date1904, location: config.Location},
+		collectErrors: config.CollectErrors,
+		progress:      config.Progress,
+		bounds:        bounds,
+	}
+	titleRowIndex, skip := config.TitleRowIndex, config.Skip
+	if config.FillMergedCells || config.TitleRowSpan > 1 {
+		rd.mergedCells = newMergedCells(merges)
 	}
-	// consider title row
 	var i = 0
-	// <= because Next() have to put the pointer to the Index row.
-	for ; i <= titleRowIndex; i++ {
-		if !rd.Next() {
-			return rd, nil
+	var err error
+	if config.NoTitleRow {
+		firstColumn := 0
+		if bounds != nil {
+			firstColumn = bounds.firstColumn
+		}
+		rd.title = newPositionalTitle(firstColumn)
+	} else {
+		// consider title row
+		// <= because Next() have to put the pointer to the Index row.
+		for ; i <= titleRowIndex; i++ {
+			if !rd.Next() {
+				return rd, nil
+			}
+		}
+		rd.title, err = newRowAsMap(rd, config.TitleRowSpan)
+		if err == nil && config.NormalizeTitles {
+			rd.title.normalize()
 		}
 	}
-	rd.title, err = newRowAsMap(rd)
+	if !config.FillMergedCells {
+		// only the title rows are filled.
+		rd.mergedCells = nil
+	}
 
 	// consider skip
 	// Next() will called before Read() so just skip cursor to the row before first data row.
@@ -500,57 +722,17 @@ func newReader(cn *connect, workSheetFileReader io.ReadCloser, titleRowIndex, sk
 		}
 	}
 	rd.schameMap = make(map[reflect.Type]*schema)
+	rd.uniques = make(uniqueValues)
+	if bounds != nil && rd.row < bounds.firstRow-1 {
+		// the rows are counted from the top of range.
+		rd.row = bounds.firstRow - 1
+	}
+	rd.emptyRowPolicy = config.EmptyRowPolicy
+	_, lined := source.(linedRows)
+	rd.gapOmitted = !lined
 	return rd, err
 }
 
-// Make a base reader to sheet
-func newBaseReaderByWorkSheetFile(cn *connect, rc io.ReadCloser) (*read, error) {
-	decoder := xml.NewDecoder(rc)
-	// step into root [xml.StartElement] token
-	func(decoder *xml.Decoder) {
-		for t, err := decoder.Token(); err == nil; t, err = decoder.Token() {
-			// [xml.ProcInst]
-			// [xml.CharData]
-			// [xml.StartElement]
-			switch t.(type) {
-			case xml.StartElement:
-				return
-			}
-		}
-	}(decoder)
-
-	err := func(decoder *xml.Decoder) error {
-		// use func block to break to 'for' range
-		for t, err := decoder.Token(); err == nil; t, err = decoder.Token() {
-			// log.Printf("%+v\n\n", t)
-			switch token := t.(type) {
-			case xml.StartElement:
-				switch token.Name.Local {
-				case _SheetData:
-					return nil
-				default:
-					if err := decoder.Skip(); err != nil {
-						return err
-					}
-				}
-			}
-		}
-		return nil
-	}(decoder)
-
-	if err != nil {
-		return nil, err
-	}
-
-	rd := &read{
-		connecter:          cn,
-		decoder:            decoder,
-		decoderReadCloseer: rc,
-	}
-
-	return rd, nil
-}
-
 // a 26-number-system to decoder/encoder column of excel
 // It's a very special system, don't use it as default system!!!
 func numOfChar(c rune) int {
@@ -571,3 +753,94 @@ func ToDecimalism(s string) int {
 
 	return res + numOfChar(ary[len(ary)-1])
 }
+
+// ToColumnName convert the index of column to the name, it's the reverse of ToDecimalism.
+// 0 => A, 25 => Z, 26 => AA ...
+func ToColumnName(i int) string {
+	var ary []byte
+	for i++; i > 0; i = (i - 1) / 26 {
+		ary = append([]byte{byte('A' + (i-1)%26)}, ary...)
+	}
+	return string(ary)
+}
+
+// parseCellRef split the reference of cell such as "B3" into column index 1 and row 3.
+func parseCellRef(ref string) (columnIndex, row int, err error) {
+	columnName := strings.TrimRight(ref, _AllNumber)
+	if strings.TrimPrefix(columnName, "$") == "" || len(columnName) == len(ref) {
+		return 0, 0, fmt.Errorf("invalid cell reference = %s", ref)
+	}
+	row, err = strconv.Atoi(ref[len(columnName):])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid cell reference = %s", ref)
+	}
+	return ToDecimalism(strings.TrimPrefix(strings.ToUpper(columnName), "$")), row, nil
+}
+
+// cellRange is the bounds of cells to read, the column index starts from 0 and the row starts from 1.
+type cellRange struct {
+	firstColumn int
+	firstRow    int
+	lastColumn  int
+	// 0 if the rows are read to the end
+	lastRow int
+}
+
+// parseCellRange parse the reference such as "A1:D5", "A10:D" and "A:D", the "$" is ignored.
+// It must contain ":" or the row, so the defined name is not taken as a column.
+func parseCellRange(ref string) (*cellRange, error) {
+	ref = strings.ToUpper(strings.ReplaceAll(ref, "$", ""))
+	first, last := ref, ref
+	if i := strings.IndexByte(ref, ':'); i >= 0 {
+		first, last = ref[:i], ref[i+1:]
+	}
+	parse := func(ref string) (column, row int, err error) {
+		columnName := strings.TrimRight(ref, _AllNumber)
+		if columnName == "" || strings.TrimLeft(columnName, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+			return 0, 0, fmt.Errorf("invalid cell range = %s", ref)
+		}
+		if rowText := ref[len(columnName):]; rowText != "" {
+			if row, err = strconv.Atoi(rowText); err != nil || row < 1 {
+				return 0, 0, fmt.Errorf("invalid cell range = %s", ref)
+			}
+		}
+		return ToDecimalism(columnName), row, nil
+	}
+	r := &cellRange{}
+	var err error
+	if r.firstColumn, r.firstRow, err = parse(first); err != nil {
+		return nil, err
+	}
+	if r.lastColumn, r.lastRow, err = parse(last); err != nil {
+		return nil, err
+	}
+	if first == last && r.firstRow == 0 {
+		return nil, fmt.Errorf("invalid cell range = %s", ref)
+	}
+	if r.firstRow == 0 {
+		r.firstRow = 1
+	}
+	if r.firstColumn > r.lastColumn {
+		r.firstColumn, r.lastColumn = r.lastColumn, r.firstColumn
+	}
+	if r.lastRow != 0 && r.firstRow > r.lastRow {
+		r.firstRow, r.lastRow = r.lastRow, r.firstRow
+	}
+	return r, nil
+}
+
+// containsRow report whether row is not after the range, the rows before it are skipped by reader.
+func (r *cellRange) containsRow(row int) bool {
+	return r.lastRow == 0 || row <= r.lastRow
+}
+
+// filter the cells in the columns of range.
+func (r *cellRange) filter(cells []*rowCell) []*rowCell {
+	dst := cells[:0]
+	for _, cell := range cells {
+		if cell.columnIndex >= r.firstColumn && cell.columnIndex <= r.lastColumn {
+			dst = append(dst, cell)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,1212 @@
+package excel
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testWorkbook build a minimal xlsx binary for test.
+type testWorkbook struct {
+	// name and content of <worksheet> of each sheet.
+	Sheets [][2]string
+	// xl/sharedStrings.xml will be omitted if nil.
+	SharedStrings []string
+	// content of <styleSheet>, xl/styles.xml will be omitted if empty.
+	Styles string
+	// content of <workbook> before <sheets>, such as <workbookPr date1904="1"/>.
+	WorkbookPr string
+	// map[sheet name]state, such as "hidden".
+	States map[string]string
+	// content of <definedNames>, it will be omitted if empty.
+	DefinedNames string
+}
+
+func (b *testWorkbook) bytes(t *testing.T) []byte {
+	t.Helper()
+	parts := map[string]string{}
+	var sheets, rels strings.Builder
+	for i, sheet := range b.Sheets {
+		state := ""
+		if b.States[sheet[0]] != "" {
+			state = ` state="` + b.States[sheet[0]] + `"`
+		}
+		fmt.Fprintf(&sheets, `<sheet name="%s" sheetId="%d" r:id="rId%d"%s/>`, sheet[0], i+1, i+1, state)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="%s" Target="worksheets/sheet%d.xml"/>`, i+1, _RelTypeWorkSheet, i+1)
+		parts[fmt.Sprintf("%s%d.xml", _WorkSheetsPrefix, i+1)] = `<worksheet xmlns="` + _NSMain + `">` + sheet[1] + `</worksheet>`
+	}
+	definedNames := ""
+	if b.DefinedNames != "" {
+		definedNames = `<definedNames>` + b.DefinedNames + `</definedNames>`
+	}
+	parts[_WorkBookPath] = `<workbook xmlns="` + _NSMain + `" xmlns:r="` + _NSRelationships + `">` + b.WorkbookPr + `<sheets>` + sheets.String() + `</sheets>` + definedNames + `</workbook>`
+	parts[_WorkBookRels] = `<Relationships xmlns="` + _NSPackageRels + `">` + rels.String() + `</Relationships>`
+	if b.SharedStrings != nil {
+		var sst strings.Builder
+		for _, s := range b.SharedStrings {
+			sst.WriteString("<si><t>" + s + "</t></si>")
+		}
+		parts[_SharedStringPath] = fmt.Sprintf(`<sst xmlns="%s" uniqueCount="%d">%s</sst>`, _NSMain, len(b.SharedStrings), sst.String())
+	}
+
+	if b.Styles != "" {
+		parts[_StylesPath] = `<styleSheet xmlns="` + _NSMain + `">` + b.Styles + `</styleSheet>`
+	}
+
+	buffer := &bytes.Buffer{}
+	zw := zip.NewWriter(buffer)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write([]byte(_XMLHeader + content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func openTestWorkbook(t *testing.T, b *testWorkbook) Connector {
+	t.Helper()
+	conn := NewConnector()
+	if err := conn.OpenBinary(b.bytes(t)); err != nil {
+		t.Fatal(err)
+	}
+	return conn
+}
+
+type InlineString struct {
+	ID   int
+	Name string
+	Note string
+}
+
+func TestReadInlineString(t *testing.T) {
+	conn := openTestWorkbook(t, &testWorkbook{
+		Sheets: [][2]string{{"InlineString", `<sheetData>` +
+			`<row r="1"><c r="A1" t="inlineStr"><is><t>ID</t></is></c><c r="B1" t="s"><v>0</v></c><c r="C1" t="inlineStr"><is><r><t>No</t></r><r><rPr><b/></rPr><t>te</t></r></is></c></row>` +
+			`<row r="2"><c r="A2"><v>1</v></c><c r="B2" t="inlineStr"><is><t>Andy</t></is></c><c r="C2" t="inlineStr"><is><r><t xml:space="preserve">rich </t></r><r><rPr><i/></rPr><t>text</t></r></is></c></row>` +
+			`<row r="3"><c r="A3"><v>2</v></c><c r="B3" t="s"><v>1</v></c><c r="C3" t="inlineStr"><is><t></t></is></c></row>` +
+			`</sheetData>`}},
+		SharedStrings: []string{"Name", "Leo"},
+	})
+	defer conn.Close()
+
+	expectList := []InlineString{
+		{ID: 1, Name: "Andy", Note: "rich text"},
+		{ID: 2, Name: "Leo"},
+	}
+	var list []InlineString
+	rd, err := conn.NewReader(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if titles := rd.GetTitles(); !reflect.DeepEqual(titles, []string{"ID", "Name", "Note"}) {
+		t.Errorf("unexpect titles: %v", titles)
+	}
+	if err = rd.ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	rd.Close()
+	if !reflect.DeepEqual(expectList, list) {
+		t.Errorf("unexpect list: \n%s", MustJsonPrettyString(list))
+	}
+
+	rd = conn.MustReader("InlineString")
+	defer rd.Close()
+	var maps []map[string]string
+	if err = rd.ReadAll(&maps); err != nil {
+		t.Fatal(err)
+	}
+	if maps[0]["Note"] != "rich text" || maps[1]["Name"] != "Leo" {
+		t.Errorf("unexpect maps: \n%s", MustJsonPrettyString(maps))
+	}
+}
+
+func TestReadCellWithoutRef(t *testing.T) {
+	// the r attributes of row and c are optional.
+	conn := openTestWorkbook(t, &testWorkbook{
+		Sheets: [][2]string{{"NoRef", `<sheetData>` +
+			`<row><c t="inlineStr"><is><t>ID</t></is></c><c t="inlineStr"><is><t>Name</t></is></c><c t="inlineStr"><is><t>Note</t></is></c></row>` +
+			`<row><c><v>1</v></c><c t="inlineStr"><is><t>Andy</t></is></c></row>` +
+			`<row r="4"><c r="B4" t="inlineStr"><is><t>Leo</t></is></c><c t="inlineStr"><is><t>skip</t></is></c></row>` +
+			`</sheetData>`}},
+	})
+	defer conn.Close()
+
+	var cells [][]Cell
+	if err := conn.MustReader("NoRef").ReadAll(&cells); err != nil {
+		t.Fatal(err)
+	}
+	if len(cells) != 2 || cells[0][1].Ref != "B2" || cells[0][1].Value != "Andy" || cells[1][2].Ref != "C4" || cells[1][2].Value != "skip" {
+		t.Errorf("unexpect cells: \n%s", MustJsonPrettyString(cells))
+	}
+}
+
+func TestReadWithoutSharedStrings(t *testing.T) {
+	conn := openTestWorkbook(t, &testWorkbook{
+		Sheets: [][2]string{{"Numbers", `<sheetData>` +
+			`<row r="1"><c r="A1" t="inlineStr"><is><t>ID</t></is></c><c r="B1" t="inlineStr"><is><t>Score</t></is></c></row>` +
+			`<row r="2"><c r="A2"><v>1</v></c><c r="B2"><v>99.5</v></c></row>` +
+			`<row r="3"><c r="A3"><v>2</v></c><c r="B3" t="s"><v>0</v></c></row>` +
+			`</sheetData>`}},
+	})
+	defer conn.Close()
+
+	rd := conn.MustReader("Numbers")
+	defer rd.Close()
+	if !rd.Next() {
+		t.Fatal("expect the first row")
+	}
+	m := map[string]string{}
+	if err := rd.Read(&m); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(m, map[string]string{"ID": "1", "Score": "99.5"}) {
+		t.Errorf("unexpect map: %v", m)
+	}
+	if !rd.Next() {
+		t.Fatal("expect the second row")
+	}
+	// the error is located at the cell like reading into struct.
+	if err := rd.Read(&m); !errors.Is(err, ErrSharedStringsNotExist) {
+		t.Errorf("expect ErrSharedStringsNotExist but got: %+v", err)
+	}
+}
+
+func TestReadSharedStringsMode(t *testing.T) {
+	modes := []SharedStringsMode{SharedStringsEager, SharedStringsLazy, SharedStringsOnDisk}
+	for _, mode := range modes {
+		t.Run(fmt.Sprint(mode), func(t *testing.T) {
+			conn := NewConnector(LoadSharedStrings(mode), TempDir(t.TempDir()))
+			if err := conn.Open(TestFilePath); err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			rd := conn.MustReader(DupSheetName)
+			defer rd.Close()
+			var list [][]string
+			if err := rd.ReadAll(&list); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(expectDuplicatedTitleSliceList, list) {
+				t.Errorf("unexpect list: \n%s", MustJsonPrettyString(list))
+			}
+		})
+	}
+}
+
+type failedCloser struct{}
+
+func (failedCloser) Close() error {
+	return errors.New("close failed")
+}
+
+func TestCloseAll(t *testing.T) {
+	dir := t.TempDir()
+	conn := NewConnector(LoadSharedStrings(SharedStringsOnDisk), TempDir(dir))
+	if err := conn.Open(TestFilePath); err != nil {
+		t.Fatal(err)
+	}
+	var list [][]string
+	if err := conn.MustReader(DupSheetName).ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	// the temp file is removed even if closing the file failed.
+	c := conn.(*connect)
+	defer c.zipReaderCloser.Close()
+	c.zipReaderCloser = failedCloser{}
+	if err := conn.Close(); err == nil || err.Error() != "close failed" {
+		t.Errorf("expect error of close but got: %v", err)
+	}
+	if files, err := os.ReadDir(dir); err != nil || len(files) != 0 {
+		t.Errorf("expect temp files removed: %v, %v", files, err)
+	}
+}
+
+func TestReadSharedStringsBadCount(t *testing.T) {
+	// the count is limited by the size of xml instead of allocated at once.
+	xml := `<sst count="4294967295" uniqueCount="2147483647"><si><t>a</t></si><si><t>b</t></si></sst>`
+	slc, err := readSharedStringsXML(strings.NewReader(xml), int64(len(xml)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(slc, []string{"a", "b"}) || cap(slc) > len(xml)/5 {
+		t.Errorf("unexpect shared strings: %v, cap %d", slc, cap(slc))
+	}
+}
+
+func TestReadSharedStringsBadXML(t *testing.T) {
+	// the error of decoder is returned instead of taking the strings before it as all.
+	for _, xml := range []string{
+		`<sst count="2"><si><t>a</t></si><si><t>b`,
+		`<sst count="2"><si><t>a</t></si><si`,
+		`<sst count="2"><si><t>a</t></si><ext><x></ext></sst>`,
+		`<sst count="2"><si><t>a</t></si><si><t>b</t></si></sst><`,
+	} {
+		if slc, err := readSharedStringsXML(strings.NewReader(xml), int64(len(xml))); err == nil {
+			t.Errorf("expect error of %s but got: %v", xml, slc)
+		}
+	}
+}
+
+func TestTempFileClose(t *testing.T) {
+	tmp, err := os.CreateTemp(t.TempDir(), "excel-spool-*.xlsx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmp.Close()
+	// the error of closing file is returned and the file is still removed.
+	if err = (&tempFile{File: tmp}).Close(); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("expect os.ErrClosed but got: %v", err)
+	}
+	if _, err = os.Stat(tmp.Name()); !os.IsNotExist(err) {
+		t.Errorf("expect temp file removed but got: %v", err)
+	}
+}
+
+func TestReopenConnector(t *testing.T) {
+	first := (&testWorkbook{
+		Sheets:       [][2]string{{"S1", `<sheetData/>`}, {"S2", `<sheetData/>`}},
+		Styles:       `<cellXfs count="2"><xf numFmtId="0"/><xf numFmtId="14"/></cellXfs>`,
+		DefinedNames: `<definedName name="Members">S1!$A$1:$B$3</definedName>`,
+	}).bytes(t)
+	second := (&testWorkbook{
+		Sheets: [][2]string{{"Other", `<sheetData>` +
+			`<row r="1"><c r="A1" t="inlineStr"><is><t>Number</t></is></c></row>` +
+			`<row r="2"><c r="A2" s="1"><v>2</v></c></row>` +
+			`</sheetData>`}},
+	}).bytes(t)
+
+	conn := NewConnector()
+	if err := conn.OpenBinary(first); err != nil {
+		t.Fatal(err)
+	}
+	// the sheets, names and styles of the workbook opened before are not kept.
+	if err := conn.OpenBinary(second); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if names := conn.GetSheetNames(); !reflect.DeepEqual(names, []string{"Other"}) {
+		t.Errorf("unexpect sheet names: %v", names)
+	}
+	if infos, err := conn.GetSheetInfos(); err != nil || len(infos) != 1 || infos[0].Name != "Other" {
+		t.Errorf("unexpect sheet infos: %+v, %v", infos, err)
+	}
+	if names := conn.GetDefinedNames(); len(names) != 0 {
+		t.Errorf("unexpect defined names: %+v", names)
+	}
+	var list []map[string]string
+	if err := conn.MustReader("Other").ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(list, []map[string]string{{"Number": "2"}}) {
+		t.Errorf("unexpect list: %v", list)
+	}
+}
+
+type DateCell struct {
+	Date   time.Time
+	Text   string `xlsx:"column(Date)"`
+	Number string
+}
+
+func TestReadDateCell(t *testing.T) {
+	const sheetData = `<sheetData>` +
+		`<row r="1"><c r="A1" t="inlineStr"><is><t>Date</t></is></c><c r="B1" t="inlineStr"><is><t>Number</t></is></c></row>` +
+		`<row r="2"><c r="A2" s="1"><v>44845.5</v></c><c r="B2" s="2"><v>44845.5</v></c></row>` +
+		`<row r="3"><c r="A3" s="3"><v>0.25</v></c><c r="B3" s="0"><v>1</v></c></row>` +
+		`</sheetData>`
+	const styles = `<numFmts count="2"><numFmt numFmtId="176" formatCode="yyyy/mm/dd"/><numFmt numFmtId="177" formatCode="0.00&quot;d&quot;"/></numFmts>` +
+		`<cellXfs count="4"><xf numFmtId="0"/><xf numFmtId="176"/><xf numFmtId="177"/><xf numFmtId="20"/></cellXfs>`
+	shanghai := time.FixedZone("CST", 8*3600)
+
+	tests := []struct {
+		name       string
+		workbookPr string
+		location   *time.Location
+		want       []DateCell
+	}{
+		{
+			name: "1900",
+			want: []DateCell{
+				{Date: time.Date(2022, 10, 11, 12, 0, 0, 0, time.UTC), Text: "2022-10-11T12:00:00Z", Number: "44845.5"},
+				{Date: time.Date(1899, 12, 30, 6, 0, 0, 0, time.UTC), Text: "1899-12-30T06:00:00Z", Number: "1"},
+			},
+		},
+		{
+			name:       "1904",
+			workbookPr: `<workbookPr date1904="1"/>`,
+			want: []DateCell{
+				{Date: time.Date(2026, 10, 12, 12, 0, 0, 0, time.UTC), Text: "2026-10-12T12:00:00Z", Number: "44845.5"},
+				{Date: time.Date(1904, 1, 1, 6, 0, 0, 0, time.UTC), Text: "1904-01-01T06:00:00Z", Number: "1"},
+			},
+		},
+		{
+			name:     "location",
+			location: shanghai,
+			want: []DateCell{
+				{Date: time.Date(2022, 10, 11, 12, 0, 0, 0, shanghai), Text: "2022-10-11T12:00:00+08:00", Number: "44845.5"},
+				{Date: time.Date(1899, 12, 30, 6, 0, 0, 0, shanghai), Text: "1899-12-30T06:00:00+08:00", Number: "1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := openTestWorkbook(t, &testWorkbook{
+				Sheets:     [][2]string{{"DateCell", sheetData}},
+				Styles:     styles,
+				WorkbookPr: tt.workbookPr,
+			})
+			defer conn.Close()
+
+			rd, err := conn.NewReaderByConfig(&Config{Sheet: "DateCell", Location: tt.location})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rd.Close()
+			var list []DateCell
+			if err = rd.ReadAll(&list); err != nil {
+				t.Fatal(err)
+			}
+			if len(list) != len(tt.want) {
+				t.Fatalf("unexpect list: \n%s", MustJsonPrettyString(list))
+			}
+			for i, got := range list {
+				want := tt.want[i]
+				if !got.Date.Equal(want.Date) || got.Text != want.Text || got.Number != want.Number {
+					t.Errorf("unexpect date cell at %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+type SerialDate struct {
+	Number time.Time
+}
+
+func TestReadSerialToTime(t *testing.T) {
+	// the numbers not formatted as date are converted in the date system and location of sheet.
+	conn := openTestWorkbook(t, &testWorkbook{
+		Sheets: [][2]string{{"Serial", `<sheetData>` +
+			`<row r="1"><c r="A1" t="inlineStr"><is><t>Number</t></is></c></row>` +
+			`<row r="2"><c r="A2"><v>44845.5</v></c></row>` +
+			`<row r="3"><c r="A3" t="inlineStr"><is><t>2022-10-11 12:00:00</t></is></c></row>` +
+			`</sheetData>`}},
+		WorkbookPr: `<workbookPr date1904="1"/>`,
+	})
+	defer conn.Close()
+
+	shanghai := time.FixedZone("CST", 8*3600)
+	rd, err := conn.NewReaderByConfig(&Config{Sheet: "Serial", Location: shanghai})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	var list []SerialDate
+	if err = rd.ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	want := []time.Time{time.Date(2026, 10, 12, 12, 0, 0, 0, shanghai), time.Date(2022, 10, 11, 12, 0, 0, 0, shanghai)}
+	if len(list) != len(want) {
+		t.Fatalf("unexpect list: \n%s", MustJsonPrettyString(list))
+	}
+	for i, got := range list {
+		if !got.Number.Equal(want[i]) {
+			t.Errorf("unexpect time at %d = %v, want %v", i, got.Number, want[i])
+		}
+	}
+}
+
+func TestReadISODateCell(t *testing.T) {
+	conn := openTestWorkbook(t, &testWorkbook{
+		Sheets: [][2]string{{"DateCell", `<sheetData>` +
+			`<row r="1"><c r="A1" t="inlineStr"><is><t>Date</t></is></c><c r="B1" t="inlineStr"><is><t>Number</t></is></c></row>` +
+			`<row r="2"><c r="A2" t="d"><v>2022-10-11T12:00:29</v></c></row>` +
+			`<row r="3"><c r="A3" t="d"><v>2022-10-11T12:00:29.5+02:00</v></c></row>` +
+			`<row r="4"><c r="A4" t="d"><v>2022-10-11</v></c></row>` +
+			`</sheetData>`}},
+	})
+	defer conn.Close()
+
+	// the date without zone is in the location of config.
+	shanghai := time.FixedZone("CST", 8*3600)
+	rd, err := conn.NewReaderByConfig(&Config{Sheet: "DateCell", Location: shanghai})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	var list []DateCell
+	if err = rd.ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	expectList := []DateCell{
+		{Date: time.Date(2022, 10, 11, 12, 0, 29, 0, shanghai), Text: "2022-10-11T12:00:29+08:00"},
+		{Date: time.Date(2022, 10, 11, 12, 0, 29, 5e8, time.FixedZone("", 2*3600)), Text: "2022-10-11T12:00:29.5+02:00"},
+		{Date: time.Date(2022, 10, 11, 0, 0, 0, 0, shanghai), Text: "2022-10-11T00:00:00+08:00"},
+	}
+	if len(list) != len(expectList) {
+		t.Fatalf("unexpect list: \n%s", MustJsonPrettyString(list))
+	}
+	for i, expect := range expectList {
+		if !list[i].Date.Equal(expect.Date) || list[i].Text != expect.Text {
+			t.Errorf("unexpect date cell at %d: %+v", i, list[i])
+		}
+	}
+
+	var maps []map[string]string
+	if err = conn.MustReader("DateCell").ReadAll(&maps); err != nil {
+		t.Fatal(err)
+	}
+	if maps[0]["Date"] != "2022-10-11T12:00:29Z" {
+		t.Errorf("unexpect maps: %v", maps)
+	}
+}
+
+func TestIsDateFormatCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"General", false},
+		{"0.00", false},
+		{"#,##0.00_);[Red](#,##0.00)", false},
+		{`0.00"days"`, false},
+		{`0\d`, false},
+		{"0.00E+00", false},
+		{"yyyy-mm-dd", true},
+		{"[$-409]m/d/yy\\ h:mm\\ AM/PM;@", true},
+		{"[h]:mm:ss", true},
+		{`[Red]"date"yyyy`, true},
+	}
+	for _, tt := range tests {
+		if got := isDateFormatCode(tt.code); got != tt.want {
+			t.Errorf("isDateFormatCode(%q) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+type BadCell struct {
+	ID     int
+	Passed bool
+	Score  float64 `xlsx:"column(Score);default(x)"`
+}
+
+const badCellSheetData = `<sheetData>` +
+	`<row r="1"><c r="A1" t="inlineStr"><is><t>ID</t></is></c><c r="B1" t="inlineStr"><is><t>Passed</t></is></c><c r="C1" t="inlineStr"><is><t>Score</t></is></c></row>` +
+	`<row r="2"><c r="A2"><v>1</v></c><c r="B2" t="inlineStr"><is><t>yes</t></is></c><c r="C2"><v>1.5</v></c></row>` +
+	`<row r="4"><c r="A4" t="inlineStr"><is><t>two</t></is></c><c r="B4" t="b"><v>1</v></c><c r="C4" t="e"><v>#N/A</v></c></row>` +
+	`<row r="5"><c r="A5"><v>3</v></c><c r="B5" t="b"><v>0</v></c></row>` +
+	`</sheetData>`
+
+func TestReadCellError(t *testing.T) {
+	conn := openTestWorkbook(t, &testWorkbook{Sheets: [][2]string{{"BadCell", badCellSheetData}}})
+	defer conn.Close()
+
+	rd := conn.MustReader("BadCell")
+	defer rd.Close()
+	var list []BadCell
+	err := rd.ReadAll(&list)
+	cellErr, ok := err.(*CellError)
+	if !ok {
+		t.Fatalf("expect *CellError but got: %+v", err)
+	}
+	expect := &CellError{Sheet: "BadCell", Row: 2, Column: "B", Title: "Passed", Field: "Passed", Value: "yes", Err: cellErr.Err}
+	if !reflect.DeepEqual(expect, cellErr) {
+		t.Errorf("unexpect cell error: %+v", cellErr)
+	}
+}
+
+func TestReadCollectErrors(t *testing.T) {
+	conn := openTestWorkbook(t, &testWorkbook{Sheets: [][2]string{{"BadCell", badCellSheetData}}})
+	defer conn.Close()
+
+	rd, err := conn.NewReaderByConfig(&Config{Sheet: "BadCell", CollectErrors: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	var list []BadCell
+	err = rd.ReadAll(&list)
+	cellErrs, ok := err.(CellErrors)
+	if !ok {
+		t.Fatalf("expect CellErrors but got: %+v", err)
+	}
+	expectCells := []string{"B2", "A4", "C4", "C5"}
+	if len(cellErrs) != len(expectCells) {
+		t.Fatalf("unexpect cell errors: %s", cellErrs)
+	}
+	for i, cellErr := range cellErrs {
+		if ref := cellErr.Column + fmt.Sprint(cellErr.Row); ref != expectCells[i] {
+			t.Errorf("unexpect cell error at %d: %s", i, cellErr)
+		}
+	}
+	var valueErr *CellValueError
+	if !errors.As(cellErrs[2], &valueErr) || valueErr.Ref != "C4" {
+		t.Errorf("expect *CellValueError but got: %+v", cellErrs[2].Err)
+	}
+	if cellErrs[3].Value != "x" || cellErrs[3].Field != "Score" {
+		t.Errorf("expect error of default value but got: %s", cellErrs[3])
+	}
+	expectList := []BadCell{{ID: 1, Score: 1.5}, {Passed: true}, {ID: 3}}
+	if !reflect.DeepEqual(expectList, list) {
+		t.Errorf("unexpect list: \n%s", MustJsonPrettyString(list))
+	}
+}
+
+func TestReadCollectErrorsToMapAndSlice(t *testing.T) {
+	conn := openTestWorkbook(t, &testWorkbook{Sheets: [][2]string{{"BadCell", badCellSheetData}}})
+	defer conn.Close()
+
+	rd, err := conn.NewReaderByConfig(&Config{Sheet: "BadCell", CollectErrors: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	var maps []map[string]string
+	err = rd.ReadAll(&maps)
+	cellErrs, ok := err.(CellErrors)
+	if !ok || len(cellErrs) != 1 || cellErrs[0].Column != "C" || cellErrs[0].Row != 4 {
+		t.Fatalf("expect CellErrors of C4 but got: %+v", err)
+	}
+	if len(maps) != 3 || maps[1]["ID"] != "two" || maps[1]["Score"] != "" {
+		t.Errorf("unexpect maps: %v", maps)
+	}
+
+	rd, err = conn.NewReaderByConfig(&Config{Sheet: "BadCell", CollectErrors: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	var rows [][]string
+	err = rd.ReadAll(&rows)
+	cellErrs, ok = err.(CellErrors)
+	if !ok || len(cellErrs) != 1 || cellErrs[0].Column != "C" || cellErrs[0].Row != 4 {
+		t.Fatalf("expect CellErrors of C4 but got: %+v", err)
+	}
+	if len(rows) != 3 || rows[1][0] != "two" || rows[1][2] != "" {
+		t.Errorf("unexpect rows: %v", rows)
+	}
+
+	// the first bad cell is returned without CollectErrors.
+	rd = conn.MustReader("BadCell")
+	defer rd.Close()
+	err = rd.ReadAll(&rows)
+	if cellErr, ok := err.(*CellError); !ok || cellErr.Column != "C" || cellErr.Row != 4 {
+		t.Errorf("expect *CellError of C4 but got: %+v", err)
+	}
+}
+
+type MergedCell struct {
+	Group string
+	Name  string
+	Score int
+}
+
+func TestReadMergedCells(t *testing.T) {
+	conn := openTestWorkbook(t, &testWorkbook{
+		Sheets: [][2]string{{"MergedCell", `<sheetData>` +
+			`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c></row>` +
+			`<row r="2"><c r="A2" t="s"><v>3</v></c><c r="B2" t="s"><v>4</v></c><c r="C2"><v>10</v></c></row>` +
+			`<row r="3"><c r="A3" s="1"/><c r="B3" t="s"><v>5</v></c><c r="C3"><v>9</v></c></row>` +
+			`<row r="4"><c r="B4" t="s"><v>6</v></c><c r="C4"><v>8</v></c></row>` +
+			`</sheetData><mergeCells count="1"><mergeCell ref="A2:A4"/></mergeCells>`}, {"MergedTitle", `<sheetData>` +
+			`<row r="1"><c r="A1" t="s"><v>7</v></c><c r="C1" t="s"><v>2</v></c></row>` +
+			`<row r="2"><c r="A2" t="s"><v>4</v></c><c r="B2" t="s"><v>5</v></c><c r="C2"><v>10</v></c></row>` +
+			`<row r="3"><c r="A3" t="s"><v>6</v></c><c r="C3"><v>9</v></c></row>` +
+			`</sheetData><mergeCells count="2"><mergeCell ref="A1:B1"/><mergeCell ref="A3:B3"/></mergeCells>`}},
+		SharedStrings: []string{"Group", "Name", "Score", "G1", "Andy", "Leo", "Tom", "Info"},
+	})
+	defer conn.Close()
+
+	expectList := []MergedCell{
+		{Group: "G1", Name: "Andy", Score: 10},
+		{Group: "G1", Name: "Leo", Score: 9},
+		{Group: "G1", Name: "Tom", Score: 8},
+	}
+	var list []MergedCell
+	rd, err := conn.NewReaderByConfig(&Config{Sheet: list, FillMergedCells: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = rd.ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	rd.Close()
+	if !reflect.DeepEqual(expectList, list) {
+		t.Errorf("unexpect list: \n%s", MustJsonPrettyString(list))
+	}
+
+	// the merged cells are left empty by default.
+	list = nil
+	rd = conn.MustReader(list)
+	if err = rd.ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	rd.Close()
+	if list[1].Group != "" || list[2].Group != "" {
+		t.Errorf("unexpect list: \n%s", MustJsonPrettyString(list))
+	}
+
+	rd = conn.MustReaderByConfig(&Config{Sheet: "MergedTitle", FillMergedCells: true})
+	defer rd.Close()
+	if titles := rd.GetTitles(); !reflect.DeepEqual(titles, []string{"Info", "Info", "Score"}) {
+		t.Errorf("unexpect titles: %v", titles)
+	}
+	var cells [][]Cell
+	if err = rd.ReadAll(&cells); err != nil {
+		t.Fatal(err)
+	}
+	expectCells := [][]Cell{
+		{{Ref: "A2", Type: CellTypeString, Value: "Andy"}, {Ref: "B2", Type: CellTypeString, Value: "Leo"}, {Ref: "C2", Value: "10"}},
+		{{Ref: "A3", Type: CellTypeString, Value: "Tom"}, {Ref: "B3", Type: CellTypeString, Value: "Tom"}, {Ref: "C3", Value: "9"}},
+	}
+	if !reflect.DeepEqual(expectCells, cells) {
+		t.Errorf("unexpect cells: \n%s", MustJsonPrettyString(cells))
+	}
+}
+
+type Address struct {
+	City string
+	Zip  string
+}
+
+type MultiRowTitle struct {
+	ID   int
+	Home Address `xlsx:"column(Address)"`
+	Work *Address
+	Zip  string `xlsx:"column(Address.Zip)"`
+	Note string
+}
+
+func TestReadMultiRowTitle(t *testing.T) {
+	conn := openTestWorkbook(t, &testWorkbook{
+		Sheets: [][2]string{{"MultiRowTitle", `<sheetData>` +
+			`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="D1" t="s"><v>4</v></c></row>` +
+			`<row r="2"><c r="B2" t="s"><v>2</v></c><c r="C2" t="s"><v>3</v></c></row>` +
+			`<row r="3"><c r="A3"><v>1</v></c><c r="B3" t="s"><v>5</v></c><c r="C3" t="s"><v>6</v></c><c r="D3" t="s"><v>7</v></c></row>` +
+			`</sheetData><mergeCells count="2"><mergeCell ref="A1:A2"/><mergeCell ref="B1:C1"/></mergeCells>`}},
+		SharedStrings: []string{"ID", "Address", "City", "Zip", "Note", "Paris", "75001", "home"},
+	})
+	defer conn.Close()
+
+	expectList := []MultiRowTitle{
+		{ID: 1, Home: Address{City: "Paris", Zip: "75001"}, Zip: "75001", Note: "home"},
+	}
+	var list []MultiRowTitle
+	rd, err := conn.NewReaderByConfig(&Config{Sheet: list, TitleRowSpan: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if titles := rd.GetTitles(); !reflect.DeepEqual(titles, []string{"ID", "Address.City", "Address.Zip", "Note"}) {
+		t.Errorf("unexpect titles: %v", titles)
+	}
+	if err = rd.ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	rd.Close()
+	if !reflect.DeepEqual(expectList, list) {
+		t.Errorf("unexpect list: \n%s", MustJsonPrettyString(list))
+	}
+
+	// the nested struct is written as columns of path.
+	b, err := Marshal(expectList)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn = NewConnector()
+	if err = conn.OpenBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	rd = conn.MustReader(list)
+	defer rd.Close()
+	if titles := rd.GetTitles(); !reflect.DeepEqual(titles, []string{"ID", "Address.City", "Address.Zip", "Work.City", "Work.Zip", "Note"}) {
+		t.Errorf("unexpect titles: %v", titles)
+	}
+	list = nil
+	if err = rd.ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expectList, list) {
+		t.Errorf("unexpect list: \n%s", MustJsonPrettyString(list))
+	}
+}
+
+func TestReadAllContext(t *testing.T) {
+	var sheetData strings.Builder
+	sheetData.WriteString(`<sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>ID</t></is></c></row>`)
+	for i := 2; i <= 5; i++ {
+		fmt.Fprintf(&sheetData, `<row r="%d"><c r="A%d"><v>%d</v></c></row>`, i, i, i-1)
+	}
+	sheetData.WriteString(`</sheetData>`)
+	conn := openTestWorkbook(t, &testWorkbook{
+		Sheets: [][2]string{{"Numbers", sheetData.String()}},
+	})
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var progresses []Progress
+	rd, err := conn.NewReaderByConfig(&Config{
+		Sheet: "Numbers",
+		Progress: func(p Progress) {
+			progresses = append(progresses, p)
+			if p.Rows == 2 {
+				cancel()
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	var list []map[string]int
+	if err = rd.ReadAllContext(ctx, &list); err != context.Canceled {
+		t.Fatalf("expect context.Canceled but got: %+v", err)
+	}
+	if !reflect.DeepEqual(list, []map[string]int{{"ID": 1}, {"ID": 2}}) {
+		t.Errorf("unexpect list: %v", list)
+	}
+	if len(progresses) != 2 || progresses[0].Rows != 1 || progresses[1].Rows != 2 {
+		t.Fatalf("unexpect progresses: %+v", progresses)
+	}
+	if p := progresses[1]; p.Bytes <= progresses[0].Bytes || p.Bytes >= p.TotalBytes {
+		t.Errorf("unexpect progress: %+v", p)
+	}
+}
+
+func TestReadRange(t *testing.T) {
+	conn := openTestWorkbook(t, &testWorkbook{
+		Sheets: [][2]string{{"Report", `<sheetData>` +
+			`<row r="1"><c r="A1" t="inlineStr"><is><t>Total</t></is></c><c r="B1" t="inlineStr"><is><t>Count</t></is></c><c r="E1" t="inlineStr"><is><t>Side</t></is></c></row>` +
+			`<row r="2"><c r="A2"><v>100</v></c><c r="B2"><v>2</v></c></row>` +
+			`<row r="4"><c r="A4" t="inlineStr"><is><t>Note</t></is></c><c r="B4" t="inlineStr"><is><t>ID</t></is></c><c r="C4" t="inlineStr"><is><t>Name</t></is></c></row>` +
+			`<row r="5"><c r="B5"><v>1</v></c><c r="C5" t="inlineStr"><is><t>Andy</t></is></c></row>` +
+			`<row r="6"><c r="A6" t="inlineStr"><is><t>x</t></is></c><c r="B6"><v>2</v></c><c r="C6" t="inlineStr"><is><t>Leo</t></is></c><c r="D6"><v>9</v></c></row>` +
+			`</sheetData>`}},
+		DefinedNames: `<definedName name="Detail">Report!$B$4:$C$6</definedName>`,
+	})
+	defer conn.Close()
+
+	rd, err := conn.NewReaderByConfig(&Config{Sheet: "Report", Range: "A1:B2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	if titles := rd.GetTitles(); !reflect.DeepEqual(titles, []string{"Total", "Count"}) {
+		t.Errorf("unexpect titles: %v", titles)
+	}
+	var summary []map[string]string
+	if err = rd.ReadAll(&summary); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(summary, []map[string]string{{"Total": "100", "Count": "2"}}) {
+		t.Errorf("unexpect summary: %v", summary)
+	}
+
+	for _, r := range []string{"Report!B4:C", "Report!$B$4:$C$6", "Detail"} {
+		rd, err := conn.NewReaderByConfig(&Config{Sheet: "Other", Range: r})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rd.Close()
+		if titles := rd.GetTitles(); !reflect.DeepEqual(titles, []string{"ID", "Name"}) {
+			t.Errorf("unexpect titles of %s: %v", r, titles)
+		}
+		var detail []map[string]string
+		if err = rd.ReadAll(&detail); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(detail, []map[string]string{{"ID": "1", "Name": "Andy"}, {"ID": "2", "Name": "Leo"}}) {
+			t.Errorf("unexpect detail of %s: %v", r, detail)
+		}
+	}
+
+	rd, err = conn.NewReaderByConfig(&Config{Sheet: "Report", Range: "B4:C6"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	var rows [][]string
+	if err = rd.ReadAll(&rows); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rows, [][]string{{"1", "Andy"}, {"2", "Leo"}}) {
+		t.Errorf("unexpect rows: %v", rows)
+	}
+
+	if _, err = conn.NewReaderByConfig(&Config{Range: "Summary"}); !errors.Is(err, ErrDefinedNameNotExist) {
+		t.Errorf("expect ErrDefinedNameNotExist but got: %v", err)
+	}
+}
+
+type Positional struct {
+	ID    int     `xlsx:"column(A)"`
+	Name  string  `xlsx:"index(1)"`
+	Score float64 `xlsx:"column(C);req()"`
+	Note  string
+}
+
+func TestReadNoTitleRow(t *testing.T) {
+	conn := NewCSVConnector(nil)
+	if err := conn.OpenBinary([]byte("1,Andy,98.5\n2,Leo,77,extra\n")); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	rd, err := conn.NewReaderByConfig(&Config{Sheet: "Positional", NoTitleRow: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	if titles := rd.GetTitles(); len(titles) != 0 {
+		t.Errorf("unexpect titles: %v", titles)
+	}
+	var list []Positional
+	if err = rd.ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(list, []Positional{{ID: 1, Name: "Andy", Score: 98.5}, {ID: 2, Name: "Leo", Score: 77}}) {
+		t.Errorf("unexpect list: \n%s", MustJsonPrettyString(list))
+	}
+
+	rd, err = conn.NewReaderByConfig(&Config{Sheet: "Positional", NoTitleRow: true, Skip: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	var maps []map[string]string
+	if err = rd.ReadAll(&maps); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(maps, []map[string]string{{"A": "2", "B": "Leo", "C": "77", "D": "extra"}}) {
+		t.Errorf("unexpect maps: %v", maps)
+	}
+
+	rd, err = conn.NewReaderByConfig(&Config{Sheet: "Positional", NoTitleRow: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	var rows [][]string
+	if err = rd.ReadAll(&rows); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rows, [][]string{{"1", "Andy", "98.5"}, {"2", "Leo", "77", "extra"}}) {
+		t.Errorf("unexpect rows: %v", rows)
+	}
+}
+
+type UntaggedPositional struct {
+	// the names of field are not column names without column tag.
+	ID  string
+	SKU string
+	Qty int `xlsx:"column(B)"`
+}
+
+type RequiredUntagged struct {
+	URL string `xlsx:"req()"`
+}
+
+func TestReadNoTitleRowUntagged(t *testing.T) {
+	conn := NewCSVConnector(nil)
+	// the column ID exists, but the field ID is not mapped to it.
+	if err := conn.OpenBinary([]byte("a1,3" + strings.Repeat(",x", 300) + "\n")); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	rd, err := conn.NewReaderByConfig(&Config{Sheet: "Positional", NoTitleRow: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	var list []UntaggedPositional
+	if err = rd.ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(list, []UntaggedPositional{{Qty: 3}}) {
+		t.Errorf("unexpect list: \n%s", MustJsonPrettyString(list))
+	}
+
+	rd, err = conn.NewReaderByConfig(&Config{Sheet: "Positional", NoTitleRow: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	var required []RequiredUntagged
+	if err = rd.ReadAll(&required); err == nil || !strings.Contains(err.Error(), "URL has no column") {
+		t.Errorf("expect the error of no column but got: %v", err)
+	}
+}
+
+type BadIndex struct {
+	ID int `xlsx:"index(-1)"`
+}
+
+func TestReadIndexTag(t *testing.T) {
+	conn := NewCSVConnector(nil)
+	if err := conn.OpenBinary([]byte("Code,Label\n1,Andy\n")); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// index works with title row too
+	var list []Positional
+	if err := conn.MustReader(&list).ReadAll(&list); err == nil {
+		t.Error("expect error of required column C")
+	}
+	type Indexed struct {
+		ID   int    `xlsx:"index(0)"`
+		Name string `xlsx:"index(1)"`
+	}
+	var indexed []Indexed
+	if err := conn.MustReaderByConfig(&Config{Sheet: "Positional"}).ReadAll(&indexed); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(indexed, []Indexed{{ID: 1, Name: "Andy"}}) {
+		t.Errorf("unexpect list: %v", indexed)
+	}
+	var bad []BadIndex
+	if err := conn.MustReader(&bad).ReadAll(&bad); err == nil {
+		t.Error("expect error of bad index(-1)")
+	}
+}
+
+type AliasContact struct {
+	Name  string `xlsx:"column(Name);alias(姓名)"`
+	Phone string `xlsx:"alias(手机号|手机号码)"`
+	Note  string `xlsx:"column((备注))"`
+	// the "|" of column is not a separator of aliases
+	Unit string `xlsx:"column(kg|g)"`
+}
+
+func TestReadColumnAliases(t *testing.T) {
+	for _, c := range []struct {
+		csv       string
+		normalize bool
+		expect    []AliasContact
+		err       error
+	}{
+		{csv: "姓名,手机号码,(备注),kg|g\nAndy,138,a,1\n", expect: []AliasContact{{Name: "Andy", Phone: "138", Note: "a", Unit: "1"}}},
+		{csv: "Name,kg\nAndy,1\n", expect: []AliasContact{{Name: "Andy"}}},
+		// no column is matched without normalizing
+		{csv: " 姓名 ,PHONE,（备注） \nAndy,138,a\n", expect: []AliasContact{}},
+		{csv: " 姓名 ,PHONE,（备注） \nAndy,138,a\n", normalize: true, expect: []AliasContact{{Name: "Andy", Phone: "138", Note: "a"}}},
+		// the exact title is used first
+		{csv: "Name,name ,Phone\nAndy,Leo,138\n", normalize: true, expect: []AliasContact{{Name: "Andy", Phone: "138"}}},
+		{csv: "Name,手机号,手机号码\nAndy,138,139\n", err: ErrAmbiguousColumn},
+		{csv: "name,NAME,Phone\nAndy,Leo,138\n", normalize: true, err: ErrAmbiguousColumn},
+	} {
+		conn := NewCSVConnector(nil)
+		if err := conn.OpenBinary([]byte(c.csv)); err != nil {
+			t.Fatal(err)
+		}
+		rd, err := conn.NewReaderByConfig(&Config{Sheet: "AliasContact", NormalizeTitles: c.normalize})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var list []AliasContact
+		err = rd.ReadAll(&list)
+		if c.err != nil {
+			if !errors.Is(err, c.err) {
+				t.Errorf("%q: expect %v but got: %v", c.csv, c.err, err)
+			}
+		} else if err != nil || !reflect.DeepEqual(list, c.expect) {
+			t.Errorf("%q: unexpect list %v, %v", c.csv, list, err)
+		}
+		rd.Close()
+		conn.Close()
+	}
+}
+
+type Product struct {
+	ID    int
+	Name  string
+	Attrs map[string]interface{} `xlsx:",rest"`
+}
+
+type ProductText struct {
+	ID    int
+	Extra map[string]string `xlsx:"rest"`
+}
+
+type BadRest struct {
+	ID    int
+	Attrs []string `xlsx:",rest"`
+}
+
+func TestReadRest(t *testing.T) {
+	conn := openTestWorkbook(t, &testWorkbook{
+		Sheets: [][2]string{{"Product", `<sheetData>` +
+			`<row r="1"><c r="A1" t="inlineStr"><is><t>ID</t></is></c><c r="B1" t="inlineStr"><is><t>Name</t></is></c>` +
+			`<c r="C1" t="inlineStr"><is><t>Color</t></is></c><c r="D1" t="inlineStr"><is><t>Weight</t></is></c><c r="F1" t="inlineStr"><is><t>Fragile</t></is></c></row>` +
+			`<row r="2"><c r="A2"><v>1</v></c><c r="B2" t="inlineStr"><is><t>Cup</t></is></c>` +
+			`<c r="C2" t="inlineStr"><is><t>red</t></is></c><c r="D2"><v>0.25</v></c><c r="E2"><v>7</v></c><c r="F2" t="b"><v>1</v></c></row>` +
+			`<row r="3"><c r="A3"><v>2</v></c><c r="B3" t="inlineStr"><is><t>Pen</t></is></c></row>` +
+			`</sheetData>`}},
+	})
+	defer conn.Close()
+
+	var list []Product
+	if err := conn.MustReader("Product").ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	expectList := []Product{
+		{ID: 1, Name: "Cup", Attrs: map[string]interface{}{"Color": "red", "Weight": 0.25, "E": float64(7), "Fragile": true}},
+		{ID: 2, Name: "Pen"},
+	}
+	if !reflect.DeepEqual(list, expectList) {
+		t.Errorf("unexpect list: \n%s", MustJsonPrettyString(list))
+	}
+
+	// the map is reset for every row
+	rd := conn.MustReader("Product")
+	defer rd.Close()
+	var row ProductText
+	var texts []map[string]string
+	for rd.Next() {
+		if err := rd.Read(&row); err != nil {
+			t.Fatal(err)
+		}
+		texts = append(texts, row.Extra)
+	}
+	if !reflect.DeepEqual(texts, []map[string]string{{"Name": "Cup", "Color": "red", "Weight": "0.25", "E": "7", "Fragile": "1"}, {"Name": "Pen"}}) {
+		t.Errorf("unexpect rest: %v", texts)
+	}
+
+	var bad []BadRest
+	if err := conn.MustReader("Product").ReadAll(&bad); err == nil {
+		t.Error("expect error of bad type of rest")
+	}
+	if c := praseTagValue("Attrs,rest"); c.ColumnName != "Attrs" || !c.Rest {
+		t.Errorf("unexpect config of Attrs,rest: %+v", c)
+	}
+	if c := praseTagValue("A,B"); c.ColumnName != "A,B" || c.Rest {
+		t.Errorf("unexpect config of A,B: %+v", c)
+	}
+}
+
+type TracedProduct struct {
+	Row     int    `xlsx:",rownum"`
+	Sheet   string `xlsx:",sheet"`
+	NameRef string `xlsx:",cellref(Name)"`
+	Missing string `xlsx:",cellref(Color)"`
+	ID      int
+	Name    string
+}
+
+type BadRowNum struct {
+	Row string `xlsx:",rownum"`
+}
+
+func TestReadRowMeta(t *testing.T) {
+	conn := openTestWorkbook(t, &testWorkbook{
+		Sheets: [][2]string{{"Product", `<sheetData>` +
+			`<row r="1"><c r="A1" t="inlineStr"><is><t>ID</t></is></c><c r="B1" t="inlineStr"><is><t>Name</t></is></c></row>` +
+			`<row r="2"><c r="A2"><v>1</v></c><c r="B2" t="inlineStr"><is><t>Cup</t></is></c></row>` +
+			`<row r="5"><c r="A5"><v>2</v></c><c r="B5" t="inlineStr"><is><t>Pen</t></is></c></row>` +
+			`</sheetData>`}},
+	})
+	defer conn.Close()
+
+	var list []TracedProduct
+	if err := conn.MustReader("Product").ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	expectList := []TracedProduct{
+		{Row: 2, Sheet: "Product", NameRef: "B2", ID: 1, Name: "Cup"},
+		{Row: 5, Sheet: "Product", NameRef: "B5", ID: 2, Name: "Pen"},
+	}
+	if !reflect.DeepEqual(list, expectList) {
+		t.Errorf("unexpect list: \n%s", MustJsonPrettyString(list))
+	}
+
+	var bad []BadRowNum
+	if err := conn.MustReader("Product").ReadAll(&bad); err == nil {
+		t.Error("expect error of bad type of rownum")
+	}
+	if c := praseTagValue(",cellref(Name)"); c.ColumnName != "" || c.CellRef != "Name" {
+		t.Errorf("unexpect config of ,cellref(Name): %+v", c)
+	}
+}
+
+type NumberedProduct struct {
+	Row  int `xlsx:",rownum"`
+	ID   int
+	Name string
+}
+
+func TestReadEmptyRowPolicy(t *testing.T) {
+	conn := openTestWorkbook(t, &testWorkbook{
+		Sheets: [][2]string{{"Product", `<sheetData>` +
+			`<row r="1"><c r="A1" t="inlineStr"><is><t>ID</t></is></c><c r="B1" t="inlineStr"><is><t>Name</t></is></c></row>` +
+			`<row r="2"><c r="A2"><v>1</v></c><c r="B2" t="inlineStr"><is><t>Cup</t></is></c></row>` +
+			`<row r="3"><c r="A3"/></row>` +
+			`<row r="5"><c r="A5"><v>2</v></c></row>` +
+			`<row r="7"><c r="B7" t="inlineStr"><is><t>Total</t></is></c></row>` +
+			`</sheetData>`}},
+	})
+	defer conn.Close()
+
+	read := func(policy EmptyRowPolicy, container interface{}) {
+		t.Helper()
+		rd, err := conn.NewReaderByConfig(&Config{Sheet: "Product", EmptyRowPolicy: policy})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rd.Close()
+		if err = rd.ReadAll(container); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var skipped, stopped, zeros []NumberedProduct
+	read(EmptyRowSkip, &skipped)
+	read(EmptyRowStop, &stopped)
+	read(EmptyRowZero, &zeros)
+	if expect := []NumberedProduct{{2, 1, "Cup"}, {5, 2, ""}, {7, 0, "Total"}}; !reflect.DeepEqual(skipped, expect) {
+		t.Errorf("unexpect skipped rows: %v", skipped)
+	}
+	if expect := []NumberedProduct{{2, 1, "Cup"}}; !reflect.DeepEqual(stopped, expect) {
+		t.Errorf("unexpect stopped rows: %v", stopped)
+	}
+	if expect := []NumberedProduct{{2, 1, "Cup"}, {3, 0, ""}, {4, 0, ""}, {5, 2, ""}, {6, 0, ""}, {7, 0, "Total"}}; !reflect.DeepEqual(zeros, expect) {
+		t.Errorf("unexpect zero rows: %v", zeros)
+	}
+
+	// the missing cells are zero value in map and slice like struct
+	var maps []map[string]string
+	read(EmptyRowZero, &maps)
+	expectMaps := []map[string]string{
+		{"ID": "1", "Name": "Cup"}, {"ID": "", "Name": ""}, {"ID": "", "Name": ""},
+		{"ID": "2", "Name": ""}, {"ID": "", "Name": ""}, {"ID": "", "Name": "Total"},
+	}
+	if !reflect.DeepEqual(maps, expectMaps) {
+		t.Errorf("unexpect maps: \n%s", MustJsonPrettyString(maps))
+	}
+	var slices [][]string
+	read(EmptyRowStop, &slices)
+	if expect := [][]string{{"1", "Cup"}}; !reflect.DeepEqual(slices, expect) {
+		t.Errorf("unexpect slices: %v", slices)
+	}
+	slices = nil
+	read(EmptyRowSkip, &slices)
+	if expect := [][]string{{"1", "Cup"}, {"2", ""}, {"", "Total"}}; !reflect.DeepEqual(slices, expect) {
+		t.Errorf("unexpect slices: %v", slices)
+	}
+}
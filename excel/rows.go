@@ -0,0 +1,89 @@
+package excel
+
+import (
+	"io"
+	"iter"
+	"reflect"
+)
+
+// Rows iterate the rows of sheet as T, T can be struct, pointer to struct, map[string]T or slice like Read,
+// a new struct is allocated for every row if T is a pointer.
+// The config can be nil, and the sheet is inferred from T if config.Sheet is nil.
+// The reader is closed when the iteration is done or broken.
+//
+// The bad cells of a row are yielded as *CellError (or CellErrors in collect-all mode) with the row,
+// and the iteration goes on, any other error is yielded with zero T and stop the iteration.
+//
+//	for v, err := range excel.Rows[Standard](conn, nil) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func Rows[T any](conn Connector, config *Config) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		if config == nil {
+			config = &Config{}
+		}
+		if config.Sheet == nil {
+			c := *config
+			c.Sheet = zero
+			if t := reflect.TypeOf(zero); t != nil && t.Kind() == reflect.Ptr {
+				// infer from the struct instead of nil pointer.
+				c.Sheet = reflect.New(t.Elem()).Interface()
+			}
+			config = &c
+		}
+		reader, err := conn.NewReaderByConfig(config)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		defer reader.Close()
+
+		readNext := func(v *T) error {
+			return reader.Read(v)
+		}
+		if rd, ok := reader.(*read); ok {
+			t := reflect.TypeOf(zero)
+			isPtr := t != nil && t.Kind() == reflect.Ptr
+			if isPtr {
+				t = t.Elem()
+			}
+			if t != nil && t.Kind() == reflect.Struct {
+				// resolve the schema once instead of every row.
+				s := rd.getSchame(t)
+				readNext = func(v *T) (err error) {
+					val := reflect.ValueOf(v).Elem()
+					if isPtr {
+						val.Set(reflect.New(t))
+						val = val.Elem()
+					}
+					for err = ErrEmptyRow; err == ErrEmptyRow; {
+						err = rd.readToValue(s, val)
+					}
+					rd.rowRead(err)
+					return err
+				}
+			}
+		}
+
+		for reader.Next() {
+			var v T
+			err := readNext(&v)
+			switch err.(type) {
+			case nil, *CellError, CellErrors:
+				if !yield(v, err) {
+					return
+				}
+			default:
+				if err != io.EOF {
+					// the last row may be empty
+					yield(zero, err)
+				}
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package excel
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRows(t *testing.T) {
+	conn := NewConnector()
+	if err := conn.Open(TestFilePath); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var stdList []Standard
+	for std, err := range Rows[Standard](conn, nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		stdList = append(stdList, std)
+	}
+	if !reflect.DeepEqual(expectStandardList, stdList) {
+		t.Errorf("unexpect stdlist: \n%s", MustJsonPrettyString(stdList))
+	}
+
+	// the struct of pointer is allocated for every row.
+	var ptrList []*Standard
+	for std, err := range Rows[*Standard](conn, nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ptrList = append(ptrList, std)
+	}
+	if len(ptrList) != len(expectStandardList) || ptrList[0] == ptrList[1] || !reflect.DeepEqual(*ptrList[0], expectStandardList[0]) {
+		t.Errorf("unexpect ptrlist: \n%s", MustJsonPrettyString(ptrList))
+	}
+
+	// break the iteration
+	count := 0
+	for range Rows[map[string]string](conn, &Config{Sheet: "Standard"}) {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("unexpect count: %d", count)
+	}
+
+	var errs []error
+	for _, err := range Rows[Standard](conn, &Config{Sheet: "NotExist"}) {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || errs[0] == nil {
+		t.Errorf("expect error of not exist sheet but got: %v", errs)
+	}
+}
+
+func TestRowsCellError(t *testing.T) {
+	conn := openTestWorkbook(t, &testWorkbook{Sheets: [][2]string{{"BadCell", badCellSheetData}}})
+	defer conn.Close()
+
+	var ids []int
+	var refs []string
+	for v, err := range Rows[BadCell](conn, nil) {
+		ids = append(ids, v.ID)
+		if cellErr, ok := err.(*CellError); ok {
+			refs = append(refs, cellErr.Column)
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the iteration goes on after bad cell.
+	if !reflect.DeepEqual(ids, []int{1, 0, 3}) || !reflect.DeepEqual(refs, []string{"B", "A", "C"}) {
+		t.Errorf("unexpect ids: %v, refs: %v", ids, refs)
+	}
+}
@@ -1,6 +1,7 @@
 package excel
 
 import (
+	"database/sql"
 	"encoding"
 	"fmt"
 	"reflect"
@@ -9,19 +10,26 @@ import (
 
 // ref: gopkg.in/redis.v5
 
-func scanByDefault(s string, ptr interface{}, def string) error {
-	err := scan(s, ptr)
+func scanByDefault(s string, ptr interface{}, def string, tc timeContext) error {
+	err := scan(s, ptr, tc)
 	if err != nil {
-		err = scan(def, ptr)
+		err = scan(def, ptr, tc)
 	}
 	return err
 }
 
-func scan(s string, ptr interface{}) error {
+// scan s into ptr, the time.Time is converted in tc.
+func scan(s string, ptr interface{}, tc timeContext) error {
+	if ptr == nil {
+		return ErrScanNil
+	}
+	if v := reflect.ValueOf(ptr); v.Kind() == reflect.Ptr && !v.IsNil() {
+		if converter, ok := getTypeConverter(v.Type().Elem()); ok {
+			return convertTo(converter, s, v.Elem())
+		}
+	}
 	var err error
 	switch p := ptr.(type) {
-	case nil:
-		return ErrScanNil
 	case *string:
 		*p = s
 	case *[]byte:
@@ -55,18 +63,28 @@ func scan(s string, ptr interface{}) error {
 	case *bool:
 		*p, err = ToBool(s)
 	case *time.Time:
-		*p, err = ToTime(s)
+		*p, err = tc.toTime(s)
+	case *Cell:
+		*p = Cell{Type: CellTypeString, Value: s}
+	case encoding.TextUnmarshaler:
+		if err = p.UnmarshalText([]byte(s)); err != nil {
+			err = fmt.Errorf("can't unmarshar by encoding.TextUnmarshaler: %s", err)
+		}
+	case sql.Scanner:
+		if err = p.Scan(s); err != nil {
+			err = fmt.Errorf("can't unmarshar by sql.Scanner: %s", err)
+		}
 	case encoding.BinaryUnmarshaler:
 		if err = p.UnmarshalBinary([]byte(s)); err != nil {
 			err = fmt.Errorf("can't unmarshar by encoding.BinaryUnmarshaler: %s", err)
 		}
 	default:
-		err = fmt.Errorf("can't unmarshal %T (consider implementing encoding.BinaryUnmarshaler)", p)
+		err = fmt.Errorf("can't unmarshal %T (consider implementing encoding.TextUnmarshaler or excel.RegisterConverter)", p)
 	}
 	return err
 }
 
-func scanSlice(data []string, sliceValue reflect.Value) error {
+func scanSlice(data []string, sliceValue reflect.Value, tc timeContext) error {
 	if !sliceValue.IsValid() {
 		return fmt.Errorf("ScanSlice(nil)")
 	}
@@ -80,7 +98,7 @@ func scanSlice(data []string, sliceValue reflect.Value) error {
 
 	for i, s := range data {
 		elem := sliceNextElem(sliceValue)
-		if err := scan(s, elem.Addr().Interface()); err != nil {
+		if err := scan(s, elem.Addr().Interface(), tc); err != nil {
 			return fmt.Errorf("ScanSlice(index=%d value=%q) failed: %s", i, s, err)
 		}
 	}
@@ -1,7 +1,11 @@
 package excel
 
 import (
+	"database/sql"
+	"encoding"
+	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -15,11 +19,29 @@ const (
 	nilTag     = "nil"
 	ignoreTag  = "-"
 	reqTag     = "req"
+	inlineTag  = "inline"
+	prefixTag  = "prefix"
+	convTag    = "conv"
+	indexTag   = "index"
+	aliasTag   = "alias"
+	restTag    = "rest"
+	rownumTag  = "rownum"
+	sheetTag   = "sheet"
+	cellRefTag = "cellref"
+
+	// json风格的标志分隔符，如",rest"
+	tagFlagSplit = ","
 )
 
 type FieldConfig struct {
 	// The config equals to tag: column
 	ColumnName string
+	// The config equals to tag: alias
+	// the other names of column separated by "|", such as "手机号|手机号码", the ColumnName is written as title.
+	Alias string
+	// The config equals to tag: index
+	// the position of column starts from 0 (column A), it's used instead of ColumnName.
+	Index string
 	// The config equals to tag: default
 	DefaultValue string
 	// The config equals to tag: split
@@ -32,16 +54,74 @@ type FieldConfig struct {
 	IsRequired bool
 	// The config equals to tag: -
 	Ignore bool
+	// The config equals to tag: inline
+	// the fields of nested struct are promoted without prefix, same as the embedded struct.
+	Inline bool
+	// The config equals to tag: prefix
+	// the prefix of columns of nested struct, default is ColumnName + "."
+	Prefix string
+	// The config equals to tag: conv
+	// the name of converter registered by RegisterNamedConverter, it converts every element if Split is set.
+	Converter string
+	// The config equals to tag: min
+	// the min number, or the min length of string, slice and map.
+	Min string
+	// The config equals to tag: max
+	// the max number, or the max length of string, slice and map.
+	Max string
+	// The config equals to tag: len
+	// the exact length of string, slice and map.
+	Len string
+	// The config equals to tag: regex
+	// the regexp the text of cell must match.
+	Regex string
+	// The config equals to tag: oneof
+	// the options of the text of cell, separated by "|".
+	OneOf string
+	// The config equals to tag: notempty
+	// the cell must not be empty, the column must exist if IsRequired.
+	NotEmpty bool
+	// The config equals to tag: unique
+	// the text of cell must be unique in the column, the empty cells are not checked.
+	Unique bool
+	// The config equals to tag: rest
+	// the field of map[string]string, map[string]interface{} or map[string]Cell receives the columns not mapped to other fields.
+	Rest bool
+	// The config equals to tag: rownum
+	// the int field receives the number of row, starts from 1.
+	RowNum bool
+	// The config equals to tag: sheet
+	// the string field receives the name of sheet.
+	Sheet bool
+	// The config equals to tag: cellref
+	// the string field receives the reference of the cell in the column of title in current row, such as "C5".
+	CellRef string
 }
 
 func (this *FieldConfig) froze(fieldIdx int) *fieldConfig {
 	return &fieldConfig{
-		FieldIndex:   fieldIdx,
+		FieldIndex:   []int{fieldIdx},
 		ColumnName:   this.ColumnName,
+		Alias:        this.Alias,
+		Index:        this.Index,
 		DefaultValue: this.DefaultValue,
 		Split:        this.Split,
 		NilValue:     this.NilValue,
 		IsRequired:   this.IsRequired,
+		Inline:       this.Inline,
+		Prefix:       this.Prefix,
+		Converter:    this.Converter,
+		Min:          this.Min,
+		Max:          this.Max,
+		Len:          this.Len,
+		Regex:        this.Regex,
+		OneOf:        this.OneOf,
+		NotEmpty:     this.NotEmpty,
+		Unique:       this.Unique,
+		Rest:         this.Rest,
+		RowNum:       this.RowNum,
+		Sheet:        this.Sheet,
+		CellRef:      this.CellRef,
 	}
 }
 
@@ -50,22 +130,80 @@ type ExcelFiledConfiger interface {
 }
 
 type fieldConfig struct {
-	FieldIndex int
+	// index sequence of the field, the nested struct field is more than one index.
+	FieldIndex []int
+	// name of the field, the nested one is joined by ".", used to report error
+	FieldName string
 	// use ptr in order to know if configed.
-	ColumnName   string
+	ColumnName string
+	// other names of column separated by "|"
+	Alias string
+	// position of column, ColumnName is not used if it's set
+	Index        string
 	DefaultValue string
 	Split        string
 	// if cell.value == NilValue, will skip fc scan
 	NilValue string
 	// panic if reuqired fc column but not set
 	IsRequired bool
+	// promote the fields of nested struct without prefix
+	Inline bool
+	// prefix of columns of nested struct
+	Prefix string
+	// name of converter
+	Converter string
+	// validation rules
+	Min      string
+	Max      string
+	Len      string
+	Regex    string
+	OneOf    string
+	NotEmpty bool
+	Unique   bool
+	// receive the columns not mapped
+	Rest bool
+	// receive the metadata of row
+	RowNum  bool
+	Sheet   bool
+	CellRef string
+	// rules compiled from Min, Max, Len, Regex and OneOf.
+	validators []*validateRule
+	// the column index parsed from Index
+	columnIndex int
+	// the other names of column parsed from Alias with prefix
+	aliases []string
+	// the ColumnName is configed instead of the name of field
+	columnTagged bool
+	// the column of CellRef
+	cellRefColumn *fieldConfig
+}
+
+// columnNames return ColumnName and its aliases.
+func (fc *fieldConfig) columnNames() []string {
+	return append([]string{fc.ColumnName}, fc.aliases...)
+}
+
+// parseIndex parse the Index into columnIndex.
+func (fc *fieldConfig) parseIndex() error {
+	if fc.Index == "" {
+		return nil
+	}
+	i, err := strconv.Atoi(fc.Index)
+	if err != nil || i < 0 || i >= _MaxColumns {
+		return fmt.Errorf("go-excel: bad index(%s) of field %s", fc.Index, fc.FieldName)
+	}
+	fc.columnIndex = i
+	return nil
 }
 
-func (fc *fieldConfig) scan(valStr string, fieldValue reflect.Value) error {
+func (fc *fieldConfig) scan(valStr string, fieldValue reflect.Value, tc timeContext) error {
 	if fc.NilValue == valStr {
 		// log.Printf("Got nil,skip")
 		return nil
 	}
+	if fc.Converter != "" {
+		return fc.convert(valStr, fieldValue)
+	}
 	var err error
 	switch fieldValue.Kind() {
 	case reflect.Slice, reflect.Array:
@@ -73,7 +211,10 @@ func (fc *fieldConfig) scan(valStr string, fieldValue reflect.Value) error {
 			// use split
 			elems := strings.Split(valStr, fc.Split)
 			fieldValue.Set(reflect.MakeSlice(fieldValue.Type(), 0, len(elems)))
-			err = scanSlice(elems, fieldValue.Addr())
+			err = scanSlice(elems, fieldValue.Addr(), tc)
+		} else if scanWhole(fieldValue.Type()) {
+			// such as []byte or net.IP
+			err = scan(valStr, fieldValue.Addr().Interface(), tc)
 		}
 	case reflect.Ptr:
 		newValue := fieldValue
@@ -83,30 +224,143 @@ func (fc *fieldConfig) scan(valStr string, fieldValue reflect.Value) error {
 				newValue = newValue.Elem()
 			}
 		}
-		err = scan(valStr, newValue.Addr().Interface())
+		err = scan(valStr, newValue.Addr().Interface(), tc)
 	default:
-		err = scan(valStr, fieldValue.Addr().Interface())
+		err = scan(valStr, fieldValue.Addr().Interface(), tc)
 	}
 	return err
 }
 
-func (fc *fieldConfig) ScanDefault(fieldValue reflect.Value) error {
-	err := fc.scan(fc.DefaultValue, fieldValue)
+// convert valStr by the named converter, every element is converted if split.
+func (fc *fieldConfig) convert(valStr string, fieldValue reflect.Value) error {
+	converter, err := getNamedConverter(fc.Converter)
+	if err != nil {
+		return err
+	}
+	if fieldValue.Kind() != reflect.Slice || len(fc.Split) == 0 {
+		return convertTo(converter, valStr, fieldValue)
+	}
+	if len(valStr) == 0 {
+		return nil
+	}
+	elems := strings.Split(valStr, fc.Split)
+	slice := reflect.MakeSlice(fieldValue.Type(), len(elems), len(elems))
+	for i, elem := range elems {
+		if err = convertTo(converter, elem, slice.Index(i)); err != nil {
+			return fmt.Errorf("convert (index=%d value=%q) failed: %s", i, elem, err)
+		}
+	}
+	fieldValue.Set(slice)
+	return nil
+}
+
+func (fc *fieldConfig) ScanDefault(fieldValue reflect.Value, tc timeContext) error {
+	err := fc.scan(fc.DefaultValue, fieldValue, tc)
 	if err != nil && len(fc.DefaultValue) > 0 {
 		return err
 	}
 	return nil
 }
 
+// field get the field of v by FieldIndex, the nil pointers to nested struct are allocated.
+func (fc *fieldConfig) field(v reflect.Value) reflect.Value {
+	for i, x := range fc.FieldIndex {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// scanWhole report whether the slice type t is scanned from the whole text instead of split.
+func scanWhole(t reflect.Type) bool {
+	switch t {
+	case bytesType, runesType:
+		return true
+	}
+	if _, ok := getTypeConverter(t); ok {
+		return true
+	}
+	ptr := reflect.PtrTo(t)
+	return ptr.Implements(textUnmarshalerType) || ptr.Implements(sqlScannerType) || ptr.Implements(binaryUnmarshalerType)
+}
+
+// lookupField get the field of v by index, return false if a nested struct is nil.
+func lookupField(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return v, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
 type schema struct {
 	Type   reflect.Type
 	Fields []*fieldConfig
+	// the field with tag rest, nil if not exist.
+	rest *fieldConfig
+	// the fields with tag rownum, sheet or cellref.
+	metas []*fieldConfig
+	// the bad config of validation, such as min(abc).
+	err error
 }
 
 func newSchema(t reflect.Type) *schema {
 	s := &schema{
-		Fields: make([]*fieldConfig, 0, t.NumField()),
+		Fields: schemaFields(t, nil, "", map[reflect.Type]bool{t: true}),
+	}
+	s.Type = t
+	fields := s.Fields[:0]
+	for _, field := range s.Fields {
+		if field.RowNum || field.Sheet || field.CellRef != "" {
+			if err := field.checkMetaType(t.FieldByIndex(field.FieldIndex).Type); err != nil && s.err == nil {
+				s.err = err
+			}
+			s.metas = append(s.metas, field)
+			continue
+		}
+		if !field.Rest {
+			fields = append(fields, field)
+			continue
+		}
+		if err := checkRestType(t.FieldByIndex(field.FieldIndex).Type); err != nil && s.err == nil {
+			s.err = fmt.Errorf("go-excel: field %s: %w", field.FieldName, err)
+		}
+		if s.rest != nil && s.err == nil {
+			s.err = fmt.Errorf("go-excel: both field %s and %s are rest", s.rest.FieldName, field.FieldName)
+		}
+		s.rest = field
+	}
+	s.Fields = fields
+	for _, field := range s.Fields {
+		if err := field.compileValidators(t.FieldByIndex(field.FieldIndex).Type); err != nil && s.err == nil {
+			s.err = err
+		}
+		if err := field.parseIndex(); err != nil && s.err == nil {
+			s.err = err
+		}
 	}
+	return s
+}
+
+// schemaFields make the configs of fields of t,
+// parent is the config of nested struct t and prefix is the prefix of its columns, nil if t is the top one.
+// parents are the types of nested structs to prevent recursion.
+func schemaFields(t reflect.Type, parent *fieldConfig, prefix string, parents map[reflect.Type]bool) []*fieldConfig {
+	fields := make([]*fieldConfig, 0, t.NumField())
+	// the fields promoted from embedded or inline struct, may be shadowed.
+	promoted := make(map[*fieldConfig]bool)
+	// map[ColumnName] of fields declared in t.
+	declared := make(map[string]bool)
 
 	// if implement the ExcelFiledConfiger
 	var selfDefinedCfgs map[string]FieldConfig
@@ -123,36 +377,104 @@ func newSchema(t reflect.Type) *schema {
 
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
+		var fieldCnf *fieldConfig
 		if selfCfg, ok := selfDefinedCfgs[field.Name]; ok {
 			// Use self defiend config first
-			if !selfCfg.Ignore {
-				frzCfg := selfCfg.froze(i)
-				if frzCfg.ColumnName == "" {
-					frzCfg.ColumnName = field.Name
-				}
-				s.Fields = append(s.Fields, frzCfg)
+			if selfCfg.Ignore {
+				continue
 			}
+			fieldCnf = selfCfg.froze(i)
 		} else if value, ok := field.Tag.Lookup(tagIdentify); ok {
 			// Use tag second
-			if value != ignoreTag {
-				fieldCnf := praseTagValue(value)
-				fieldCnf.FieldIndex = i
-				if fieldCnf.ColumnName == "" {
-					fieldCnf.ColumnName = field.Name
-				}
-				s.Fields = append(s.Fields, fieldCnf)
+			if value == ignoreTag {
+				continue
 			}
+			fieldCnf = praseTagValue(value)
 		} else {
 			// use default config
-			fieldCnf := &fieldConfig{
-				FieldIndex: i,
-				ColumnName: field.Name,
+			fieldCnf = &fieldConfig{}
+		}
+		fieldCnf.FieldIndex = []int{i}
+		fieldCnf.FieldName = field.Name
+		if field.Anonymous && fieldCnf.ColumnName == "" {
+			// the embedded struct is inline unless the column is named.
+			fieldCnf.Inline = true
+		}
+		fieldCnf.columnTagged = fieldCnf.ColumnName != ""
+		if !fieldCnf.columnTagged {
+			fieldCnf.ColumnName = field.Name
+		}
+		fieldCnf.ColumnName = prefix + fieldCnf.ColumnName
+		if fieldCnf.Alias != "" {
+			for _, alias := range strings.Split(fieldCnf.Alias, _ColumnAliasSep) {
+				fieldCnf.aliases = append(fieldCnf.aliases, prefix+alias)
 			}
-			s.Fields = append(s.Fields, fieldCnf)
 		}
+		if parent != nil {
+			fieldCnf.FieldIndex = append(parent.FieldIndex[:len(parent.FieldIndex):len(parent.FieldIndex)], i)
+			fieldCnf.FieldName = parent.FieldName + _TitlePathSep + fieldCnf.FieldName
+		}
+
+		if nested := nestedStruct(field); nested != nil && !parents[nested] && fieldCnf.Converter == "" {
+			// the fields of nested struct are mapped to columns like "Address.City".
+			nestedPrefix := fieldCnf.ColumnName + _TitlePathSep
+			if fieldCnf.Inline {
+				nestedPrefix = prefix
+			} else if fieldCnf.Prefix != "" {
+				nestedPrefix = prefix + fieldCnf.Prefix
+			}
+			parents[nested] = true
+			nestedFields := schemaFields(nested, fieldCnf, nestedPrefix, parents)
+			delete(parents, nested)
+			for _, nestedField := range nestedFields {
+				promoted[nestedField] = fieldCnf.Inline
+			}
+			fields = append(fields, nestedFields...)
+			continue
+		}
+		declared[fieldCnf.ColumnName] = true
+		fields = append(fields, fieldCnf)
 	}
-	s.Type = t
-	return s
+
+	// the promoted field is shadowed by the field declared in t, like go does.
+	dst := fields[:0]
+	for _, field := range fields {
+		if !promoted[field] || !declared[field.ColumnName] {
+			dst = append(dst, field)
+		}
+	}
+	return dst
+}
+
+var (
+	binaryUnmarshalerType = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+	textUnmarshalerType   = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	sqlScannerType        = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	bytesType             = reflect.TypeOf([]byte(nil))
+	runesType             = reflect.TypeOf([]rune(nil))
+)
+
+// nestedStruct return the struct type if field is a nested struct (or ptr to struct),
+// the struct can be scanned from a cell such as time.Time or the one with converter is not nested.
+func nestedStruct(field reflect.StructField) reflect.Type {
+	t := field.Type
+	if field.PkgPath != "" && !(field.Anonymous && t.Kind() == reflect.Struct) {
+		// unexported, only the fields of embedded struct (not ptr) can be set.
+		return nil
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == timeType || t == cellType {
+		return nil
+	}
+	if ptr := reflect.PtrTo(t); ptr.Implements(binaryUnmarshalerType) || ptr.Implements(textUnmarshalerType) || ptr.Implements(sqlScannerType) {
+		return nil
+	}
+	if _, ok := getTypeConverter(t); ok {
+		return nil
+	}
+	return t
 }
 
 func praseTagValue(v string) *fieldConfig {
@@ -163,6 +485,22 @@ func praseTagValue(v string) *fieldConfig {
 		if param == "" {
 			continue
 		}
+		// the flags are not column names.
+		if setTagFlag(c, param) {
+			continue
+		}
+		// the flags after column like json, such as ",rest", "Attrs,rest" and ",cellref(Name)".
+		if i := strings.Index(param, tagFlagSplit); i >= 0 && isTagFlags(param[i+1:]) {
+			if key, column := getTagParam(param[:i]); key == columnTag {
+				if column != "" {
+					c.ColumnName = column
+				}
+				for _, flag := range strings.Split(param[i+1:], tagFlagSplit) {
+					setTagFlag(c, flag)
+				}
+				continue
+			}
+		}
 		cnfKey, cnfVal := getTagParam(param)
 		fillField(c, cnfKey, cnfVal)
 	}
@@ -170,10 +508,46 @@ func praseTagValue(v string) *fieldConfig {
 	return c
 }
 
+// setTagFlag set the flag of tag without param, return false if it's not a flag.
+func setTagFlag(c *fieldConfig, flag string) bool {
+	switch flag {
+	case inlineTag:
+		c.Inline = true
+	case notEmptyTag:
+		c.NotEmpty = true
+	case uniqueTag:
+		c.Unique = true
+	case restTag:
+		c.Rest = true
+	case rownumTag:
+		c.RowNum = true
+	case sheetTag:
+		c.Sheet = true
+	default:
+		if key, value := getTagParam(flag); key == cellRefTag {
+			c.CellRef = value
+			return true
+		}
+		return false
+	}
+	return true
+}
+
+// isTagFlags report whether every one of flags separated by "," is a flag.
+func isTagFlags(flags string) bool {
+	for _, flag := range strings.Split(flags, tagFlagSplit) {
+		if !setTagFlag(&fieldConfig{}, flag) {
+			return false
+		}
+	}
+	return true
+}
+
 func getTagParam(v string) (key, value string) {
 	// expect v = `field_name` or `column(fieldName)` or `default(0)` and so on ...
 	start := strings.Index(v, "(")
-	end := strings.Index(v, ")")
+	// the value may contain ")", such as regex(^(\d+)$)
+	end := strings.LastIndex(v, ")")
 	if start > 0 && end == len(v)-1 {
 		return v[:start], v[start+1 : end]
 	}
@@ -193,5 +567,68 @@ func fillField(c *fieldConfig, k, v string) {
 		c.NilValue = v
 	case reqTag:
 		c.IsRequired = true
+	case inlineTag:
+		c.Inline = true
+	case prefixTag:
+		c.Prefix = v
+	case convTag:
+		c.Converter = v
+	case indexTag:
+		c.Index = v
+	case aliasTag:
+		c.Alias = v
+	case minTag:
+		c.Min = v
+	case maxTag:
+		c.Max = v
+	case lenTag:
+		c.Len = v
+	case regexTag:
+		c.Regex = v
+	case oneofTag:
+		c.OneOf = v
+	case notEmptyTag:
+		c.NotEmpty = true
+	case uniqueTag:
+		c.Unique = true
+	case restTag:
+		c.Rest = true
+	case rownumTag:
+		c.RowNum = true
+	case sheetTag:
+		c.Sheet = true
+	case cellRefTag:
+		c.CellRef = v
+	}
+}
+
+// checkRestType check the type of field with tag rest.
+func checkRestType(t reflect.Type) error {
+	if t.Kind() != reflect.Map || t.Key().Kind() != reflect.String {
+		return fmt.Errorf("rest should be map[string]string, map[string]interface{} or map[string]Cell, but got %s", t)
+	}
+	switch elem := t.Elem(); {
+	case elem.Kind() == reflect.String, elem == cellType, elem.Kind() == reflect.Interface && elem.NumMethod() == 0:
+		return nil
 	}
+	return fmt.Errorf("rest should be map[string]string, map[string]interface{} or map[string]Cell, but got %s", t)
+}
+
+// checkMetaType check the type of field with tag rownum, sheet or cellref.
+func (fc *fieldConfig) checkMetaType(t reflect.Type) error {
+	switch {
+	case fc.RowNum:
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return nil
+		}
+		return fmt.Errorf("go-excel: rownum of field %s should be int, but got %s", fc.FieldName, t)
+	case t.Kind() != reflect.String:
+		return fmt.Errorf("go-excel: sheet or cellref of field %s should be string, but got %s", fc.FieldName, t)
+	}
+	if fc.CellRef != "" {
+		fc.cellRefColumn = &fieldConfig{FieldName: fc.FieldName, ColumnName: fc.CellRef}
+	}
+	return nil
 }
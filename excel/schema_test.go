@@ -0,0 +1,86 @@
+package excel
+
+import (
+	"reflect"
+	"testing"
+)
+
+type BaseModel struct {
+	ID   int
+	Note string
+}
+
+type audit struct {
+	Operator string
+}
+
+type Contact struct {
+	Phone string
+	Email string
+}
+
+type Embedded struct {
+	BaseModel
+	audit
+	// shadow the Note of BaseModel
+	Note  string
+	Name  string
+	Phone Contact  `xlsx:"inline"`
+	Work  *Contact `xlsx:"column(Work);prefix(Work_)"`
+	Home  Contact
+}
+
+func TestNewSchemaEmbedded(t *testing.T) {
+	s := newSchema(reflect.TypeOf(Embedded{}))
+	type column struct {
+		Name  string
+		Index []int
+	}
+	var columns []column
+	for _, field := range s.Fields {
+		columns = append(columns, column{Name: field.ColumnName, Index: field.FieldIndex})
+	}
+	expectColumns := []column{
+		{Name: "ID", Index: []int{0, 0}},
+		{Name: "Operator", Index: []int{1, 0}},
+		{Name: "Note", Index: []int{2}},
+		{Name: "Name", Index: []int{3}},
+		{Name: "Phone", Index: []int{4, 0}},
+		{Name: "Email", Index: []int{4, 1}},
+		{Name: "Work_Phone", Index: []int{5, 0}},
+		{Name: "Work_Email", Index: []int{5, 1}},
+		{Name: "Home.Phone", Index: []int{6, 0}},
+		{Name: "Home.Email", Index: []int{6, 1}},
+	}
+	if !reflect.DeepEqual(expectColumns, columns) {
+		t.Errorf("unexpect columns: \n%s", MustJsonPrettyString(columns))
+	}
+
+	expectList := []Embedded{{
+		BaseModel: BaseModel{ID: 1},
+		audit:     audit{Operator: "admin"},
+		Note:      "remark",
+		Name:      "Andy",
+		Phone:     Contact{Phone: "10086", Email: "andy@example.com"},
+		Work:      &Contact{Email: "andy@work.com"},
+		Home:      Contact{Phone: "10010"},
+	}}
+	b, err := Marshal(expectList)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn := NewConnector()
+	if err = conn.OpenBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	var list []Embedded
+	rd := conn.MustReader(list)
+	defer rd.Close()
+	if err = rd.ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expectList, list) {
+		t.Errorf("unexpect list: \n%s", MustJsonPrettyString(list))
+	}
+}
@@ -0,0 +1,240 @@
+package excel
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// SharedStringsMode decide how to load the table of xl/sharedStrings.xml
+type SharedStringsMode int
+
+const (
+	// SharedStringsEager load all shared strings into memory when open, it's the default mode.
+	SharedStringsEager SharedStringsMode = iota
+	// SharedStringsLazy load all shared strings into memory when the first one is used,
+	// the table is never loaded if no cell refers to it.
+	SharedStringsLazy
+	// SharedStringsOnDisk spool the shared strings into a temp file when the first one is used,
+	// only the offsets are kept in memory and every string is read from the file on demand.
+	SharedStringsOnDisk
+)
+
+// sharedStrings is the table of xl/sharedStrings.xml
+type sharedStrings interface {
+	get(id int) (string, error)
+	close() error
+}
+
+func newSharedStrings(file *zip.File, option *connectOption) (sharedStrings, error) {
+	if file == nil {
+		// it's valid for a workbook with only numbers or inline strings.
+		return &emptySharedStrings{}, nil
+	}
+	switch option.sharedStringsMode {
+	case SharedStringsLazy:
+		return &lazySharedStrings{load: func() (sharedStrings, error) {
+			return loadMemorySharedStrings(file)
+		}}, nil
+	case SharedStringsOnDisk:
+		return &lazySharedStrings{load: func() (sharedStrings, error) {
+			return loadDiskSharedStrings(file, option.tempDir)
+		}}, nil
+	default:
+		return loadMemorySharedStrings(file)
+	}
+}
+
+type emptySharedStrings struct{}
+
+func (*emptySharedStrings) get(id int) (string, error) {
+	return "", ErrSharedStringsNotExist
+}
+
+func (*emptySharedStrings) close() error {
+	return nil
+}
+
+type memorySharedStrings []string
+
+func loadMemorySharedStrings(file *zip.File) (sharedStrings, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	slc, err := readSharedStringsXML(rc, int64(file.UncompressedSize64))
+	if err != nil {
+		return nil, err
+	}
+	return memorySharedStrings(slc), nil
+}
+
+func (ss memorySharedStrings) get(id int) (string, error) {
+	if id < 0 || id >= len(ss) {
+		return "", fmt.Errorf("shared string id = %d out of range [0,%d)", id, len(ss))
+	}
+	return ss[id], nil
+}
+
+func (ss memorySharedStrings) close() error {
+	return nil
+}
+
+// diskSharedStrings keep the strings in a temp file,
+// the i'th string is file[offsets[i]:offsets[i+1]].
+type diskSharedStrings struct {
+	file    *os.File
+	offsets []int64
+}
+
+func loadDiskSharedStrings(file *zip.File, dir string) (sharedStrings, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	tmp, err := os.CreateTemp(dir, "excel-shared-strings-*")
+	if err != nil {
+		return nil, err
+	}
+	ss := &diskSharedStrings{
+		file:    tmp,
+		offsets: []int64{0},
+	}
+	buffer := bufio.NewWriter(tmp)
+	var offset int64
+	err = walkSharedStringsXML(rc, int64(file.UncompressedSize64), func(count int) {
+		ss.offsets = make([]int64, 1, count+1)
+	}, func(s string) error {
+		n, err := buffer.WriteString(s)
+		offset += int64(n)
+		ss.offsets = append(ss.offsets, offset)
+		return err
+	})
+	if err == nil {
+		err = buffer.Flush()
+	}
+	if err != nil {
+		ss.close()
+		return nil, err
+	}
+	return ss, nil
+}
+
+func (ss *diskSharedStrings) get(id int) (string, error) {
+	if id < 0 || id >= len(ss.offsets)-1 {
+		return "", fmt.Errorf("shared string id = %d out of range [0,%d)", id, len(ss.offsets)-1)
+	}
+	buf := make([]byte, ss.offsets[id+1]-ss.offsets[id])
+	if _, err := ss.file.ReadAt(buf, ss.offsets[id]); err != nil && err != io.EOF {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+func (ss *diskSharedStrings) close() error {
+	return errors.Join(ss.file.Close(), os.Remove(ss.file.Name()))
+}
+
+// lazySharedStrings load the table at the first time of get.
+type lazySharedStrings struct {
+	once  sync.Once
+	load  func() (sharedStrings, error)
+	table sharedStrings
+	err   error
+}
+
+func (ss *lazySharedStrings) get(id int) (string, error) {
+	ss.once.Do(func() {
+		ss.table, ss.err = ss.load()
+	})
+	if ss.err != nil {
+		return "", fmt.Errorf("read shared string failed: %s", ss.err)
+	}
+	return ss.table.get(id)
+}
+
+func (ss *lazySharedStrings) close() error {
+	// prevent loading after close
+	ss.once.Do(func() {
+		ss.err = ErrConnectNotOpened
+	})
+	if ss.table != nil {
+		return ss.table.close()
+	}
+	return nil
+}
+
+func readSharedStringsXML(rc io.Reader, size int64) ([]string, error) {
+	var slc []string
+	err := walkSharedStringsXML(rc, size, func(count int) {
+		slc = make([]string, 0, count)
+	}, func(s string) error {
+		slc = append(slc, s)
+		return nil
+	})
+	return slc, err
+}
+
+// walkSharedStringsXML call onCount with the count declared by sst,
+// then call onString with every si in order.
+// The count is not trusted and limited by the size of xml, every si takes 5 bytes ("<si/>") at least.
+func walkSharedStringsXML(rc io.Reader, size int64, onCount func(count int), onString func(s string) error) error {
+	decoder := xml.NewDecoder(rc)
+	si := &xlsxSI{}
+	for {
+		t, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		token, ok := t.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch token.Name.Local {
+		case _SST:
+			count := 0
+			unqCount := 0
+			for _, attr := range token.Attr {
+				switch attr.Name.Local {
+				case _Count:
+					count, err = ToInt(attr.Value)
+					if err != nil {
+						return err
+					}
+				case _UniqueCount:
+					unqCount, err = ToInt(attr.Value)
+					if err != nil {
+						return err
+					}
+				}
+			}
+			if unqCount != 0 {
+				count = unqCount
+			}
+			onCount(int(max(0, min(int64(count), size/5))))
+		case _SI:
+			*si = xlsxSI{}
+			if err = decoder.DecodeElement(si, &token); err != nil {
+				return err
+			}
+			if err = onString(si.String()); err != nil {
+				return err
+			}
+		default:
+			if err = decoder.Skip(); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package excel
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// worksheet表里的使用范围
+	_Dimension = "dimension"
+	// 范围属性
+	_Ref = "ref"
+
+	// workbook表里sheet的state属性：隐藏
+	_SheetStateHidden = "hidden"
+	// workbook表里sheet的state属性：只能由VBA取消的隐藏
+	_SheetStateVeryHidden = "veryHidden"
+)
+
+var (
+	// ErrDefinedNameNotExist means the defined name is not in the workbook.
+	ErrDefinedNameNotExist = errors.New("defined name not exist")
+	// ErrDefinedNameNotRange means the defined name refers to a formula or multiple ranges instead of a range of a sheet.
+	ErrDefinedNameNotRange = errors.New("defined name is not a range of sheet")
+)
+
+// SheetVisibility is the visibility of a sheet.
+type SheetVisibility int
+
+const (
+	// SheetVisible is shown in the tabs.
+	SheetVisible SheetVisibility = iota
+	// SheetHidden is hidden, but can be unhidden by user.
+	SheetHidden
+	// SheetVeryHidden is hidden and can only be unhidden by VBA.
+	SheetVeryHidden
+)
+
+func (v SheetVisibility) String() string {
+	switch v {
+	case SheetVisible:
+		return "visible"
+	case SheetHidden:
+		return "hidden"
+	case SheetVeryHidden:
+		return "veryHidden"
+	default:
+		return fmt.Sprintf("SheetVisibility(%d)", int(v))
+	}
+}
+
+// parseSheetState parse the state attribute of sheet in xl/workbook.xml.
+func parseSheetState(state string) SheetVisibility {
+	switch state {
+	case _SheetStateHidden:
+		return SheetHidden
+	case _SheetStateVeryHidden:
+		return SheetVeryHidden
+	default:
+		return SheetVisible
+	}
+}
+
+// SheetInfo is the metadata of a sheet.
+type SheetInfo struct {
+	// Name of the sheet.
+	Name string
+	// Position of the sheet in workbook, starts from 1, the hidden sheets are counted.
+	Index int
+	// Visibility of the sheet.
+	Visibility SheetVisibility
+	// The used range recorded by the writer of file, such as "A1:D10", empty if unknown.
+	// It's not always accurate, such as the formatted empty cells are counted.
+	Dimension string
+	// The number of rows estimated from Dimension, 0 if unknown.
+	Rows int
+}
+
+// DefinedName is a name of workbook, such as a named range.
+type DefinedName struct {
+	// Name of the defined name.
+	Name string
+	// Name of the sheet if the name is local to it, empty if it's global.
+	Scope string
+	// The formula of the name, such as "Sheet1!$A$1:$D$10".
+	RefersTo string
+	// Whether the name is hidden from user.
+	Hidden bool
+}
+
+// Range parse RefersTo as a range of sheet, such as "Sheet1" and "A1:D10" or the whole columns "A:D".
+func (dn *DefinedName) Range() (sheet string, ref string, err error) {
+	refersTo := strings.TrimPrefix(strings.TrimSpace(dn.RefersTo), "=")
+	i := strings.LastIndex(refersTo, "!")
+	if i <= 0 {
+		return "", "", fmt.Errorf("%w: %s refers to %s", ErrDefinedNameNotRange, dn.Name, dn.RefersTo)
+	}
+	sheet, ref = refersTo[:i], strings.ReplaceAll(refersTo[i+1:], "$", "")
+	sheet = unquoteSheetName(sheet)
+	if _, err = parseCellRange(ref); err != nil || strings.ContainsAny(sheet, "(),") {
+		return "", "", fmt.Errorf("%w: %s refers to %s", ErrDefinedNameNotRange, dn.Name, dn.RefersTo)
+	}
+	return sheet, ref, nil
+}
+
+// resolveDefinedName find name in names and parse it as a range,
+// name may be qualified by sheet as "Sheet1!Name" to find the one local to the sheet.
+func resolveDefinedName(names []DefinedName, name string) (sheet string, ref string, err error) {
+	scope := ""
+	if i := strings.LastIndex(name, "!"); i > 0 {
+		scope, name = unquoteSheetName(name[:i]), name[i+1:]
+	}
+	for i := range names {
+		if strings.EqualFold(names[i].Name, name) && names[i].Scope == scope {
+			return names[i].Range()
+		}
+	}
+	return "", "", fmt.Errorf("%w: %s", ErrDefinedNameNotExist, name)
+}
+
+// unquoteSheetName unquote the sheet name of reference such as 'My Sheet'!A1.
+func unquoteSheetName(sheet string) string {
+	if strings.HasPrefix(sheet, "'") && strings.HasSuffix(sheet, "'") && len(sheet) > 1 {
+		return strings.ReplaceAll(sheet[1:len(sheet)-1], "''", "'")
+	}
+	return sheet
+}
+
+// dimensionRows estimate the number of rows by the dimension, such as 10 for "A1:D10".
+func dimensionRows(dimension string) int {
+	if dimension == "" {
+		return 0
+	}
+	m, err := parseMergeCell(dimension)
+	if err != nil {
+		return 0
+	}
+	return m.lastRow - m.firstRow + 1
+}
+
+// readDimension read the ref of dimension element at the head of worksheet, empty if not exist.
+func readDimension(rc io.Reader) (string, error) {
+	decoder := xml.NewDecoder(rc)
+	for t, err := decoder.Token(); err != io.EOF; t, err = decoder.Token() {
+		if err != nil {
+			return "", err
+		}
+		token, ok := t.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch token.Name.Local {
+		case _Dimension:
+			for _, a := range token.Attr {
+				if a.Name.Local == _Ref {
+					return a.Value, nil
+				}
+			}
+			return "", nil
+		case _SheetData:
+			// the dimension is before sheetData if exist.
+			return "", nil
+		}
+	}
+	return "", nil
+}
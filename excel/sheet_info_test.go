@@ -0,0 +1,114 @@
+package excel
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSheetInfos(t *testing.T) {
+	b := &testWorkbook{
+		States: map[string]string{"S2": "hidden", "S3": "veryHidden"},
+		DefinedNames: `<definedName name="Members">'S1'!$A$1:$B$3</definedName>` +
+			`<definedName name="Members" localSheetId="1">S2!$B$2:$C$4</definedName>` +
+			`<definedName name="_xlnm._FilterDatabase" localSheetId="0" hidden="1">S1!$A$1:$B$3</definedName>` +
+			`<definedName name="Rate">0.05</definedName>` +
+			`<definedName name="Areas">S1!$A$1,S1!$C$3</definedName>` +
+			`<definedName name="Columns">S1!$A:$D</definedName>`,
+	}
+	var expectNames []string
+	for i := 1; i <= 12; i++ {
+		name := fmt.Sprintf("S%d", i)
+		b.Sheets = append(b.Sheets, [2]string{name, `<sheetData/>`})
+		expectNames = append(expectNames, name)
+	}
+	b.Sheets[0][1] = `<dimension ref="A1:B3"/><sheetData><row r="1"><c r="A1"><v>1</v></c></row></sheetData>`
+	conn := openTestWorkbook(t, b)
+	defer conn.Close()
+
+	// the order of names is stable
+	for i := 0; i < 5; i++ {
+		if names := conn.GetSheetNames(); !reflect.DeepEqual(names, expectNames) {
+			t.Fatalf("unexpect sheet names: %v", names)
+		}
+	}
+	infos, err := conn.GetSheetInfos()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 12 {
+		t.Fatalf("unexpect sheet infos: %+v", infos)
+	}
+	expectInfos := []SheetInfo{
+		{Name: "S1", Index: 1, Visibility: SheetVisible, Dimension: "A1:B3", Rows: 3},
+		{Name: "S2", Index: 2, Visibility: SheetHidden},
+		{Name: "S3", Index: 3, Visibility: SheetVeryHidden},
+	}
+	if !reflect.DeepEqual(infos[:3], expectInfos) {
+		t.Errorf("unexpect sheet infos: %+v", infos[:3])
+	}
+
+	if names := conn.GetDefinedNames(); len(names) != 6 || names[1].Scope != "S2" || !names[2].Hidden || names[2].Scope != "S1" {
+		t.Errorf("unexpect defined names: %+v", names)
+	}
+	for _, c := range []struct {
+		name, sheet, ref string
+		err              error
+	}{
+		{name: "Members", sheet: "S1", ref: "A1:B3"},
+		{name: "members", sheet: "S1", ref: "A1:B3"},
+		{name: "S2!Members", sheet: "S2", ref: "B2:C4"},
+		{name: "Rate", err: ErrDefinedNameNotRange},
+		{name: "Areas", err: ErrDefinedNameNotRange},
+		{name: "Columns", sheet: "S1", ref: "A:D"},
+		{name: "S3!Members", err: ErrDefinedNameNotExist},
+	} {
+		sheet, ref, err := conn.ResolveDefinedName(c.name)
+		if sheet != c.sheet || ref != c.ref || !errors.Is(err, c.err) {
+			t.Errorf("resolve %s: unexpect %s, %s, %v", c.name, sheet, ref, err)
+		}
+	}
+}
+
+func TestSheetInfosODS(t *testing.T) {
+	conn := NewConnector()
+	if err := conn.OpenBinary(testODSContent(t, `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+	xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0"
+	xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0">
+<office:automatic-styles>
+	<style:style style:name="ta1" style:family="table"><style:table-properties table:display="true"/></style:style>
+	<style:style style:name="ta2" style:family="table"><style:table-properties table:display="false"/></style:style>
+</office:automatic-styles>
+<office:body><office:spreadsheet>
+<table:table table:name="Shown" table:style-name="ta1"/>
+<table:table table:name="My Sheet" table:style-name="ta2"/>
+<table:named-expressions>
+	<table:named-range table:name="Members" table:base-cell-address="$'My Sheet'.$A$1" table:cell-range-address="$'My Sheet'.$A$1:.$B$3"/>
+	<table:named-expression table:name="Rate" table:base-cell-address="$Shown.$A$1" table:expression="of:=0.05"/>
+</table:named-expressions>
+</office:spreadsheet></office:body>
+</office:document-content>`)); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	infos, err := conn.GetSheetInfos()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectInfos := []SheetInfo{
+		{Name: "Shown", Index: 1, Visibility: SheetVisible},
+		{Name: "My Sheet", Index: 2, Visibility: SheetHidden},
+	}
+	if !reflect.DeepEqual(infos, expectInfos) {
+		t.Errorf("unexpect sheet infos: %+v", infos)
+	}
+	if sheet, ref, err := conn.ResolveDefinedName("Members"); err != nil || sheet != "My Sheet" || ref != "A1:B3" {
+		t.Errorf("unexpect Members: %s, %s, %v", sheet, ref, err)
+	}
+	if _, _, err := conn.ResolveDefinedName("Rate"); !errors.Is(err, ErrDefinedNameNotRange) {
+		t.Errorf("expect ErrDefinedNameNotRange but got: %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package excel
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrSheetNotExist means the sheet is not in the workbook.
+var ErrSheetNotExist = errors.New("sheet not exist")
+
+// workbook is the sheets of a kind of file, such as xlsx and xls.
+type workbook interface {
+	// sheetNames return the names of all sheets.
+	sheetNames() []string
+	// sheetInfos return the metadata of all sheets in workbook order.
+	sheetInfos() ([]SheetInfo, error)
+	// definedNames return the defined names of workbook.
+	definedNames() []DefinedName
+	// is1904 report whether the serial numbers of dates are in the 1904 date system.
+	is1904() bool
+	// sheetNameOf return the name of i'th sheet, "" if not exist.
+	sheetNameOf(i int) string
+	// openSheet open the rows of sheet, the merged cells are returned if required by config.
+	openSheet(sheet string, config *Config) (rowSource, []*mergeCell, error)
+	close() error
+}
+
+// linedRows is the rowSource numbering the rows by the line they start at, such as csv,
+// the numbers are not contiguous if a record takes more than one line, so no row is omitted between them.
+type linedRows interface {
+	linedRows()
+}
+
+// rowSource is the rows of a sheet.
+type rowSource interface {
+	// next move the cursor to the start of next row, return false if there is no more row.
+	next() bool
+	// readRow read the non-empty cells of the row at cursor, or the next row if the cursor is not at the start of a row.
+	// the cells are sorted by column, return io.EOF if there is no more row.
+	readRow() ([]*rowCell, error)
+	// rowNumber return the number of current row, starts from 1.
+	rowNumber() int
+	// progress return the bytes consumed and the total bytes of the sheet.
+	progress() (consumed, total int64)
+	close() error
+}
+
+// memoryRow is a row loaded into memory.
+type memoryRow struct {
+	// number of row, starts from 1
+	number int
+	// non-empty cells sorted by column
+	cells []*rowCell
+}
+
+// memoryRows is the rowSource of the sheet loaded into memory, the empty rows are omitted.
+type memoryRows struct {
+	rows []*memoryRow
+	// index of the row at cursor
+	cursor int
+	// whether the cursor is at the start of row
+	started bool
+	// size of the sheet
+	size int64
+}
+
+func (mr *memoryRows) next() bool {
+	if mr.started {
+		mr.cursor++
+	}
+	mr.started = mr.cursor < len(mr.rows)
+	return mr.started
+}
+
+func (mr *memoryRows) readRow() ([]*rowCell, error) {
+	if mr.cursor >= len(mr.rows) {
+		return nil, io.EOF
+	}
+	row := mr.rows[mr.cursor]
+	mr.cursor++
+	mr.started = false
+	cells := make([]*rowCell, len(row.cells))
+	copy(cells, row.cells)
+	return cells, nil
+}
+
+func (mr *memoryRows) rowNumber() int {
+	if mr.started || mr.cursor == 0 {
+		if mr.cursor < len(mr.rows) {
+			return mr.rows[mr.cursor].number
+		}
+		return 0
+	}
+	return mr.rows[mr.cursor-1].number
+}
+
+func (mr *memoryRows) progress() (consumed, total int64) {
+	if len(mr.rows) == 0 {
+		return mr.size, mr.size
+	}
+	return mr.size * int64(mr.cursor) / int64(len(mr.rows)), mr.size
+}
+
+func (mr *memoryRows) close() error {
+	mr.rows = nil
+	return nil
+}
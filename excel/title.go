@@ -1,10 +1,18 @@
 package excel
 
 import (
-	"encoding/xml"
 	"fmt"
 	"reflect"
 	"strings"
+
+	"golang.org/x/text/width"
+)
+
+const (
+	// 多行标题的列名分隔符，如"Address.City"
+	_TitlePathSep = "."
+	// 别名的分隔符，如alias(手机号|手机号码)
+	_ColumnAliasSep = "|"
 )
 
 type titleRow struct {
@@ -15,11 +23,19 @@ type titleRow struct {
 
 	// sorted titles
 	titles []string
+	// column index of the first title, it's not 0 if the range of reader is not started from column A
+	firstColumn int
+	// there is no title row, the columns are found by position
+	positional bool
+	// map[normalized title][]column index, nil if the titles are not normalized
+	normalized map[string][]int
 
 	typeFieldMap map[reflect.Type]map[int][]*fieldConfig
 }
 
-func newRowAsMap(rd *read) (r *titleRow, err error) {
+// newRowAsMap read span rows as title, the cursor should be in the first title row.
+// The titles of a column in multiple rows are joined by "." as a path, such as "Address.City".
+func newRowAsMap(rd *read, span int) (r *titleRow, err error) {
 	defer func() {
 		if rc := recover(); rc != nil {
 			err = fmt.Errorf("%s", rc)
@@ -30,69 +46,152 @@ func newRowAsMap(rd *read) (r *titleRow, err error) {
 		srcMap: make(map[int]string),
 		titles: make([]string, 0),
 	}
-	tempCell := &xlsxC{}
-	for t, err := rd.decoder.Token(); err == nil; t, err = rd.decoder.Token() {
-		switch token := t.(type) {
-		case xml.StartElement:
-			switch token.Name.Local {
-			case _RowPrefix:
-				// start of row
-			case _C:
-				// start of row.cell
-				tempCell.R = ""
-				tempCell.T = ""
-				tempCell.V = ""
-				tempCell.columnIndex = -1
-				for _, a := range token.Attr {
-					switch a.Name.Local {
-					case _R:
-						tempCell.R = a.Value
-					case _T:
-						tempCell.T = a.Value
-					}
-				}
-			case _V:
-				// start of row.cell.value
+	if span < 1 {
+		span = 1
+	}
+	// map[columnIndex]parts of title path
+	paths := make(map[int][]string)
+	lastColumn := -1
+	for i := 0; i < span; i++ {
+		cells, err := rd.readRow()
+		if err != nil {
+			if i == 0 {
+				return nil, ErrNoRow
 			}
-		case xml.EndElement:
-			switch token.Name.Local {
-			case _RowPrefix:
-				// end of row
-				r.typeFieldMap = make(map[reflect.Type]map[int][]*fieldConfig)
-				return r, nil
-			case _C:
-				// end of row.cell
-				for i := len(r.titles); i < tempCell.columnIndex; i++ {
-					// fill the skipped empty cell with blank
-					const blankText = ""
-					r.dstMap[blankText] = i
-					r.srcMap[i] = blankText
-					r.titles = append(r.titles, blankText)
-				}
-				r.dstMap[tempCell.V] = tempCell.columnIndex
-				r.srcMap[tempCell.columnIndex] = tempCell.V
-				r.titles = append(r.titles, tempCell.V)
-			case _V:
-				// end of row.cell.value
+			break
+		}
+		for _, cell := range cells {
+			if cell.err != nil {
+				return nil, cell.err
 			}
-		case xml.CharData:
-			trimedColumnName := strings.TrimRight(tempCell.R, _AllNumber)
-			tempCell.columnIndex = ToDecimalism(trimedColumnName)
-
-			if tempCell.T == _S {
-				// get string from shared
-				tokenInt, err := ToInt(string(token))
-				if err != nil {
-					return nil, err
-				}
-				tempCell.V = rd.connecter.getSharedString(tokenInt)
-			} else {
-				tempCell.V = string(token)
+			parts := paths[cell.columnIndex]
+			if cell.Value == "" || (len(parts) > 0 && parts[len(parts)-1] == cell.Value) {
+				// the title merged down is counted once.
+				continue
+			}
+			paths[cell.columnIndex] = append(parts, cell.Value)
+			if cell.columnIndex > lastColumn {
+				lastColumn = cell.columnIndex
 			}
 		}
 	}
+	if rd.bounds != nil {
+		r.firstColumn = rd.bounds.firstColumn
+	}
+	for i := r.firstColumn; i <= lastColumn; i++ {
+		// the skipped empty cell is filled with blank
+		title := strings.Join(paths[i], _TitlePathSep)
+		r.dstMap[title] = i
+		r.srcMap[i] = title
+		r.titles = append(r.titles, title)
+	}
+	r.typeFieldMap = make(map[reflect.Type]map[int][]*fieldConfig)
+	return r, nil
+}
 
-	return nil, ErrNoRow
+// newPositionalTitle make the title of sheet without title row, the columns are found by position.
+func newPositionalTitle(firstColumn int) *titleRow {
+	return &titleRow{
+		dstMap:       make(map[string]int),
+		srcMap:       make(map[int]string),
+		titles:       make([]string, 0),
+		firstColumn:  firstColumn,
+		positional:   true,
+		typeFieldMap: make(map[reflect.Type]map[int][]*fieldConfig),
+	}
+}
+
+// columnOf return the column index of field, by Index, the title,
+// or the column name such as "C" configed by column tag if there is no title row.
+// It returns ErrAmbiguousColumn if the names of field match more than one column.
+func (tr *titleRow) columnOf(field *fieldConfig) (int, bool, error) {
+	if field.Index != "" {
+		return field.columnIndex, true, nil
+	}
+	if tr.positional {
+		// the name of field such as "ID" is not a column name.
+		if !field.columnTagged {
+			return 0, false, nil
+		}
+		for _, name := range field.columnNames() {
+			if i, ok := columnNameIndex(name); ok {
+				return i, true, nil
+			}
+		}
+		return 0, false, nil
+	}
+	var columns []int
+	for _, name := range field.columnNames() {
+		for _, i := range tr.lookup(name) {
+			if !containsInt(columns, i) {
+				columns = append(columns, i)
+			}
+		}
+	}
+	switch len(columns) {
+	case 0:
+		return 0, false, nil
+	case 1:
+		return columns[0], true, nil
+	}
+	titles := make([]string, len(columns))
+	for j, i := range columns {
+		titles[j] = fmt.Sprintf("%q(%s)", tr.srcMap[i], ToColumnName(i))
+	}
+	return 0, false, fmt.Errorf("go-excel: %w: %s matches titles %s", ErrAmbiguousColumn, field.FieldName, strings.Join(titles, ", "))
+}
+
+// lookup return the columns of title name, the exact one is used first.
+func (tr *titleRow) lookup(name string) []int {
+	if i, ok := tr.dstMap[name]; ok {
+		return []int{i}
+	}
+	if tr.normalized == nil {
+		return nil
+	}
+	return tr.normalized[normalizeTitle(name)]
+}
+
+// normalize the titles to be matched by normalizeTitle.
+func (tr *titleRow) normalize() {
+	tr.normalized = make(map[string][]int, len(tr.titles))
+	for i, title := range tr.titles {
+		if key := normalizeTitle(title); key != "" {
+			tr.normalized[key] = append(tr.normalized[key], tr.firstColumn+i)
+		}
+	}
+}
+
+// normalizeTitle fold the full-width chars to half-width, trim and collapse the spaces, and ignore the case.
+func normalizeTitle(title string) string {
+	title = width.Fold.String(title)
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
+
+func containsInt(list []int, v int) bool {
+	for _, i := range list {
+		if i == v {
+			return true
+		}
+	}
+	return false
+}
+
+// titleOf return the title of column, or the column name if there is no title row.
+func (tr *titleRow) titleOf(columnIndex int) string {
+	if tr.positional {
+		return ToColumnName(columnIndex)
+	}
+	return tr.srcMap[columnIndex]
+}
+
+// columnNameIndex return the index of column name in "A" ... "XFD".
+func columnNameIndex(name string) (int, bool) {
+	if name == "" || len(name) > 3 || strings.TrimLeft(name, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return 0, false
+	}
+	i := ToDecimalism(name)
+	return i, i < _MaxColumns
 }
 
 // return: a copy of map[ColumnIndex][]*fieldConfig
@@ -102,12 +201,16 @@ func (tr *titleRow) MapToFields(s *schema) (rowToFiled map[int][]*fieldConfig, e
 		fieldsMap = make(map[int][]*fieldConfig)
 		for _, field := range s.Fields {
 			var cloIndex int
-			// Use ColumnName to find index
-			if i, ok := tr.dstMap[field.ColumnName]; ok {
+			// Use Index, ColumnName or the 26-number-system to find index
+			i, ok, err := tr.columnOf(field)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
 				cloIndex = i
+			} else if field.IsRequired && tr.positional && !field.columnTagged {
+				return nil, fmt.Errorf("go-excel: field %s has no column without title row, use column(C) or index(2)", field.FieldName)
 			} else if field.IsRequired {
-				// Use 26-number-system to find
-				// cloIndex = twentysix.ToDecimalism(field.ColumnName)
 				return nil, fmt.Errorf("go-excel: column name = \"%s\" is not exist", field.ColumnName)
 			} else {
 				// continue if is not required.
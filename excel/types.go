@@ -1,5 +1,11 @@
 package excel
 
+import (
+	"context"
+	"io"
+	"time"
+)
+
 // Config of connecter
 type Config struct {
 	// sheet: if sheet is string, will use sheet as sheet name.
@@ -10,12 +16,69 @@ type Config struct {
 	Sheet interface{}
 	// Use the index row as title, every row before title-row will be ignore, default is 0.
 	TitleRowIndex int
+	// Use n rows from TitleRowIndex as title, default is 0 (same as 1).
+	// The titles of a column are joined by "." as a path, such as "Address.City" under the merged "Address",
+	// which can be used in tag `xlsx:"column(Address.City)"` or mapped onto the field City of nested struct Address.
+	// The merged cells are always filled in the title rows if n > 1.
+	TitleRowSpan int
 	// Skip n row after title, default is 0 (not skip), empty row is not counted.
 	Skip int
 	// Auto prefix to sheet name.
 	Prefix string
 	// Auto suffix to sheet name.
 	Suffix string
+	// The location of the date cells, excel only stores the wall clock, default is time.UTC.
+	// The date cells are read as RFC3339 text, such as "2022-10-11T12:00:29Z".
+	// It's also used to read the numbers and the text without zone into time.Time.
+	Location *time.Location
+	// Collect all bad cells instead of stopping at the first one.
+	// Read returns CellErrors of the row and ReadAll returns CellErrors of all rows after reading the whole sheet,
+	// the rows with bad cells are kept and the bad fields, map values or slice elements are left as zero value.
+	CollectErrors bool
+	// Fill the value of merged cells into every cell covered by them, including the title row.
+	// The value of top-left cell is filled down and across, so does the metadata read as Cell.
+	FillMergedCells bool
+	// Report the progress after every row read, keep it fast since it's called in the reading loop.
+	Progress func(Progress)
+	// Read the cells in the range only, default is the whole sheet.
+	// It's a reference such as "A1:D5", "A10:D" (from row 10 to the end), "A:D" and "Sheet1!A1:D5",
+	// or a defined name such as "Members" and "Sheet1!Members", the sheet of range is used instead of Sheet.
+	// TitleRowIndex and Skip count from the top row of range.
+	Range string
+	// There is no title row, the first row (after Skip) is data and TitleRowIndex is ignored.
+	// The columns are found by position, such as `xlsx:"column(C)"` or `xlsx:"index(2)"`,
+	// the fields without column or index tag have no column even if the name of field is such as "ID".
+	// and the keys of map are the column names such as "A".
+	NoTitleRow bool
+	// Match the titles after normalizing, the spaces are trimmed, the full-width chars are folded to half-width
+	// and the case is ignored, such as "（手机号） " matches column "(手机号)". The exact title is matched first.
+	NormalizeTitles bool
+	// How to handle the empty rows after title, default is EmptyRowSkip.
+	// A row is empty if it has no value in the range, including the rows omitted by xlsx, xls and ods file.
+	// The blank lines of csv are skipped when parsing, they are not empty rows but the records without value are.
+	EmptyRowPolicy EmptyRowPolicy
+}
+
+// EmptyRowPolicy is the way to handle the empty rows.
+type EmptyRowPolicy int
+
+const (
+	// EmptyRowSkip skip the empty rows, a row without the value of any field is skipped as well when read to struct.
+	EmptyRowSkip EmptyRowPolicy = iota
+	// EmptyRowStop stop reading at the first empty row, such as the blank row before the footer of table.
+	EmptyRowStop
+	// EmptyRowZero return the empty rows as zero value, the default of fields are not filled.
+	EmptyRowZero
+)
+
+// Progress of reading a sheet.
+type Progress struct {
+	// Number of rows read, the title and skipped empty rows are not counted.
+	Rows int
+	// Bytes consumed of the uncompressed worksheet file.
+	Bytes int64
+	// Uncompressed size of the worksheet file.
+	TotalBytes int64
 }
 
 // Reader to read excel
@@ -27,26 +90,60 @@ type Reader interface {
 	// Read all rows
 	// container: container should be ptr to slice or array.
 	ReadAll(container interface{}) error
+	// Read all rows until ctx is done, return ctx.Err() if done and the rows read before are kept in container.
+	// container: container should be ptr to slice or array.
+	ReadAllContext(ctx context.Context, container interface{}) error
 	// Read next rows
 	Next() bool
 	// Close the reader
 	Close() error
 }
 
+// Writer to write excel
+type Writer interface {
+	// Start a new sheet, the rows written after will be put into it.
+	// sheetNamer: if sheetNamer is string, will use sheet as sheet name.
+	//             if sheetNamer is a object implements `GetXLSXSheetName()string`, the return value will be used.
+	// 	           if sheetNamer is a slice, the type of element will be used to infer like before.
+	//             otherwise, will use sheetNamer as struct and reflect for it's name.
+	NewSheet(sheetNamer interface{}) error
+	// Write a struct (or ptr to struct) as a row of current sheet,
+	// the title row is written before the first row.
+	Write(v interface{}) error
+	// Write all elements of container as rows of current sheet.
+	// container: container should be slice or array of struct (or ptr to struct).
+	WriteAll(container interface{}) error
+	// Flush all pending parts and close the writer, the underlying io.Writer is not closed.
+	Close() error
+}
+
 // An Connector of excel file
 type Connector interface {
-	// Open a file of excel
+	// Open a file of excel, the .xlsx or .xls is detected by the signature of file
 	Open(filePath string) error
 	// Open a binary of excel
 	OpenBinary(xlsxData []byte) error
 	// Open a file from uri
 	OpenFromUri(uri string) error
+	// Open a file from uri, the fetching is canceled when ctx is done.
+	OpenFromUriContext(ctx context.Context, uri string) error
+	// Open a excel of size bytes from r, r is not closed by the connector.
+	OpenReaderAt(r io.ReaderAt, size int64) error
+	// Open a excel from r, the input larger than SpoolThreshold is spooled into a temp file.
+	OpenReader(r io.Reader) error
 
 	// Close file reader
 	Close() error
 
-	// Get all sheets name
+	// Get all sheets name in workbook order
 	GetSheetNames() []string
+	// Get the metadata of all sheets in workbook order, such as visibility and dimension
+	GetSheetInfos() ([]SheetInfo, error)
+	// Get the defined names (named ranges) of workbook
+	GetDefinedNames() []DefinedName
+	// Resolve a defined name to the sheet and range it refers to, such as "Sheet1" and "A1:D10",
+	// the name local to a sheet is qualified by the sheet, such as "Sheet1!Name".
+	ResolveDefinedName(name string) (sheet string, ref string, err error)
 
 	// Generate an new reader of a sheet
 	// sheetNamer: if sheetNamer is string, will use sheet as sheet name.
@@ -0,0 +1,258 @@
+package excel
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	minTag      = "min"
+	maxTag      = "max"
+	lenTag      = "len"
+	regexTag    = "regex"
+	oneofTag    = "oneof"
+	notEmptyTag = "notempty"
+	uniqueTag   = "unique"
+
+	// oneof的候选值分隔符
+	_OneOfSep = "|"
+)
+
+// ValidationError is the cause of CellError if the cell breaks a validation tag, such as `xlsx:"min(1)"`.
+type ValidationError struct {
+	// The name of rule, such as "min".
+	Rule string
+	// The param of rule, such as "1", empty if no param.
+	Param string
+	// Why the cell is invalid.
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Param != "" {
+		return fmt.Sprintf("validate %s(%s) failed: %s", e.Rule, e.Param, e.Reason)
+	}
+	return fmt.Sprintf("validate %s failed: %s", e.Rule, e.Reason)
+}
+
+// RowValidator can be implemented by the struct of row to check across fields,
+// it's called after all fields of the row are read without error.
+type RowValidator interface {
+	// Return a *FieldError (or some of them by errors.Join) to locate the error at the column of field,
+	// other errors are reported on the whole row.
+	ValidateXLSXRow() error
+}
+
+// FieldError is the error of a field returned by RowValidator.
+type FieldError struct {
+	// The name of field, the nested one is joined by ".", such as "Address.City".
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field %s: %s", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// validateRule check the value of a field.
+type validateRule struct {
+	name  string
+	param string
+	// check the text of cell and the field read from it, return the reason if invalid.
+	check func(text string, v reflect.Value) string
+}
+
+// compileValidators make the rules of tags for field of type t.
+func (fc *fieldConfig) compileValidators(t reflect.Type) error {
+	fc.validators = nil
+	if fc.Min != "" {
+		if err := fc.addBoundRule(minTag, fc.Min, t, func(size, bound float64) bool { return size >= bound }, "less than"); err != nil {
+			return err
+		}
+	}
+	if fc.Max != "" {
+		if err := fc.addBoundRule(maxTag, fc.Max, t, func(size, bound float64) bool { return size <= bound }, "greater than"); err != nil {
+			return err
+		}
+	}
+	if fc.Len != "" {
+		n, err := strconv.Atoi(fc.Len)
+		if err != nil {
+			return fmt.Errorf("go-excel: bad len(%s) of field %s: %w", fc.Len, fc.FieldName, err)
+		}
+		if kind := indirectType(t).Kind(); kind != reflect.String && kind != reflect.Slice && kind != reflect.Array && kind != reflect.Map {
+			return fmt.Errorf("go-excel: len(%s) is not supported by field %s of %s", fc.Len, fc.FieldName, t)
+		}
+		fc.validators = append(fc.validators, &validateRule{name: lenTag, param: fc.Len, check: func(text string, v reflect.Value) string {
+			if size, ok := sizeOf(v); ok && int(size) != n {
+				return fmt.Sprintf("length is %d", int(size))
+			}
+			return ""
+		}})
+	}
+	if fc.Regex != "" {
+		re, err := regexp.Compile(fc.Regex)
+		if err != nil {
+			return fmt.Errorf("go-excel: bad regex(%s) of field %s: %w", fc.Regex, fc.FieldName, err)
+		}
+		fc.validators = append(fc.validators, &validateRule{name: regexTag, param: fc.Regex, check: func(text string, v reflect.Value) string {
+			if !re.MatchString(text) {
+				return "not matched"
+			}
+			return ""
+		}})
+	}
+	if fc.OneOf != "" {
+		options := strings.Split(fc.OneOf, _OneOfSep)
+		fc.validators = append(fc.validators, &validateRule{name: oneofTag, param: fc.OneOf, check: func(text string, v reflect.Value) string {
+			for _, option := range options {
+				if text == option {
+					return ""
+				}
+			}
+			return "not one of the options"
+		}})
+	}
+	return nil
+}
+
+func (fc *fieldConfig) addBoundRule(name, param string, t reflect.Type, ok func(size, bound float64) bool, reason string) error {
+	bound, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return fmt.Errorf("go-excel: bad %s(%s) of field %s: %w", name, param, fc.FieldName, err)
+	}
+	switch indirectType(t).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+	default:
+		return fmt.Errorf("go-excel: %s(%s) is not supported by field %s of %s", name, param, fc.FieldName, t)
+	}
+	fc.validators = append(fc.validators, &validateRule{name: name, param: param, check: func(text string, v reflect.Value) string {
+		if size, valid := sizeOf(v); valid && !ok(size, bound) {
+			return fmt.Sprintf("%s %s", reason, param)
+		}
+		return ""
+	}})
+	return nil
+}
+
+// validate the field v read from the text of cell, the rules are skipped for the nil value.
+func (fc *fieldConfig) validate(text string, v reflect.Value) *ValidationError {
+	if fc.NotEmpty && strings.TrimSpace(text) == "" {
+		return &ValidationError{Rule: notEmptyTag, Reason: "cell is empty"}
+	}
+	if text == "" || (fc.NilValue != "" && text == fc.NilValue) {
+		return nil
+	}
+	for _, rule := range fc.validators {
+		if reason := rule.check(text, v); reason != "" {
+			return &ValidationError{Rule: rule.name, Param: rule.param, Reason: reason}
+		}
+	}
+	return nil
+}
+
+// sizeOf return the number of v, or the length of string (in chars), slice and map.
+func sizeOf(v reflect.Value) (float64, bool) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return 0, false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.String:
+		return float64(utf8.RuneCountInString(v.String())), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(v.Len()), true
+	}
+	return 0, false
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// uniqueValues remember the first row of every value of the unique fields.
+type uniqueValues map[*fieldConfig]map[string]int
+
+// check whether text of field is seen before row.
+func (u uniqueValues) check(field *fieldConfig, text string, row int) *ValidationError {
+	if !field.Unique || text == "" {
+		return nil
+	}
+	seen, ok := u[field]
+	if !ok {
+		seen = make(map[string]int)
+		u[field] = seen
+	}
+	if first, ok := seen[text]; ok && first != row {
+		return &ValidationError{Rule: uniqueTag, Reason: fmt.Sprintf("duplicated with row %d", first)}
+	}
+	seen[text] = row
+	return nil
+}
+
+// rowValidatorErrors locate the errors returned by RowValidator.
+func (rd *read) rowValidatorErrors(s *schema, cells []*rowCell, err error) []*CellError {
+	var errs []error
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	} else {
+		errs = []error{err}
+	}
+	cellErrs := make([]*CellError, 0, len(errs))
+	for _, err := range errs {
+		var fieldErr *FieldError
+		if errors.As(err, &fieldErr) {
+			if cellErr := rd.fieldError(s, cells, fieldErr); cellErr != nil {
+				cellErrs = append(cellErrs, cellErr)
+				continue
+			}
+		}
+		cellErrs = append(cellErrs, &CellError{Sheet: rd.sheet, Row: rd.row, Err: err})
+	}
+	return cellErrs
+}
+
+// fieldError locate the error at the column of field, return nil if the field is not read from any column.
+func (rd *read) fieldError(s *schema, cells []*rowCell, fieldErr *FieldError) *CellError {
+	for _, field := range s.Fields {
+		if field.FieldName != fieldErr.Field {
+			continue
+		}
+		columnIndex, ok, _ := rd.title.columnOf(field)
+		if !ok {
+			return nil
+		}
+		value := ""
+		for _, cell := range cells {
+			if cell.columnIndex == columnIndex {
+				value = cell.Value
+				break
+			}
+		}
+		return rd.cellError(columnIndex, field, value, fieldErr.Err)
+	}
+	return nil
+}
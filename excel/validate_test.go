@@ -0,0 +1,106 @@
+package excel
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type Member struct {
+	ID     int      `xlsx:"column(ID);unique;min(1)"`
+	Name   string   `xlsx:"column(Name);notempty;max(6)"`
+	Phone  string   `xlsx:"column(Phone);regex(^1(\\d{10})$)"`
+	Level  string   `xlsx:"column(Level);oneof(gold|silver)"`
+	Code   string   `xlsx:"column(Code);len(4)"`
+	Tags   []string `xlsx:"column(Tags);split(|);max(2)"`
+	Start  int      `xlsx:"column(Start)"`
+	End    int      `xlsx:"column(End)"`
+	Remark *string  `xlsx:"column(Remark);nil(-);min(2)"`
+}
+
+func (m *Member) ValidateXLSXRow() error {
+	if m.End < m.Start {
+		return errors.Join(&FieldError{Field: "End", Err: errors.New("end is before start")}, errors.New("bad period"))
+	}
+	return nil
+}
+
+const memberCSV = "ID,Name,Phone,Level,Code,Tags,Start,End,Remark\n" +
+	"1,Andy,13800000000,gold,A001,a|b,1,2,-\n" +
+	"0,Leo Chen,1380000,bronze,A1,a|b|c,1,2,x\n" +
+	"1,,13800000001,silver,B002,,1,2,ok\n" +
+	"3,Mia,13800000002,gold,C003,,3,1,ok\n"
+
+func TestValidate(t *testing.T) {
+	conn := NewCSVConnector(nil)
+	if err := conn.OpenBinary([]byte(memberCSV)); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	rd, err := conn.NewReaderByConfig(&Config{Sheet: "Member", CollectErrors: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	var list []Member
+	err = rd.ReadAll(&list)
+	var cellErrs CellErrors
+	if !errors.As(err, &cellErrs) {
+		t.Fatalf("expect CellErrors but got: %v", err)
+	}
+	if len(list) != 4 || list[0].Remark != nil {
+		t.Errorf("unexpect list: \n%s", MustJsonPrettyString(list))
+	}
+
+	type located struct {
+		Row    int
+		Column string
+		Rule   string
+	}
+	var got []located
+	for _, cellErr := range cellErrs {
+		l := located{Row: cellErr.Row, Column: cellErr.Column}
+		var validateErr *ValidationError
+		if errors.As(cellErr, &validateErr) {
+			l.Rule = validateErr.Rule
+		}
+		got = append(got, l)
+	}
+	expect := []located{
+		{3, "A", "min"},
+		{3, "B", "max"},
+		{3, "C", "regex"},
+		{3, "D", "oneof"},
+		{3, "E", "len"},
+		{3, "F", "max"},
+		{3, "I", "min"},
+		{4, "A", "unique"},
+		{4, "B", "notempty"},
+		{5, "H", ""},
+		{5, "", ""},
+	}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("unexpect errors: %v", got)
+	}
+	if msg := cellErrs[len(cellErrs)-1].Error(); msg != "sheet Member row 5: bad period" {
+		t.Errorf("unexpect message of row error: %s", msg)
+	}
+}
+
+type BadValidation struct {
+	ID int `xlsx:"column(ID);min(one)"`
+}
+
+func TestValidateBadConfig(t *testing.T) {
+	conn := NewCSVConnector(nil)
+	if err := conn.OpenBinary([]byte("ID\n1\n")); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	var list []BadValidation
+	if err := conn.MustReader(&list).ReadAll(&list); err == nil {
+		t.Error("expect error of bad min(one)")
+	}
+}
@@ -0,0 +1,501 @@
+package excel
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// 样式表
+	_StylesPath = "xl/styles.xml"
+	// 各个部件的类型声明
+	_ContentTypesPath = "[Content_Types].xml"
+	// 包的根关系
+	_RootRels = "_rels/.rels"
+
+	_XMLHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+
+	_NSMain          = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	_NSRelationships = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	_NSPackageRels   = "http://schemas.openxmlformats.org/package/2006/relationships"
+
+	_RelTypeOfficeDocument = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument"
+	_RelTypeStyles         = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles"
+	_RelTypeSharedStrings  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings"
+
+	// cellXfs的下标，见writeStyles
+	_StyleDateTime = "1"
+
+	// 单个sheet的限制
+	_MaxRows      = 1048576
+	_MaxColumns   = 16384
+	_MaxSheetName = 31
+)
+
+var (
+	// ErrWriterClosed means the writer has been closed.
+	ErrWriterClosed = errors.New("writer has been closed")
+
+	timeType = reflect.TypeOf(time.Time{})
+)
+
+// write is default implement of writer
+type write struct {
+	zipWriter *zip.Writer
+	// the sheet writing now, rows are streamed into it.
+	sheet *sheetWriter
+	// list of written sheet name, sorted.
+	sheets []string
+
+	// all unique strings, the index is the id in xl/sharedStrings.xml
+	sharedStrings []string
+	// map["string"]id
+	sharedStringIDs map[string]int
+	// count of cells refer to shared strings
+	sharedStringRefs int
+
+	closed bool
+}
+
+type sheetWriter struct {
+	name   string
+	buffer *bufio.Writer
+	schema *schema
+	// one field for one column, a column mapped by more than one field uses the first one.
+	// The field with index(n) is at n, the others fill the columns left in order, nil if no field.
+	columns []*fieldConfig
+	// the last written row, 1-based
+	rowIndex int
+}
+
+// NewWriter make a new writer to write a xlsx file into w.
+// The rows are streamed into the worksheet, so the memory used only grows with the shared strings.
+func NewWriter(w io.Writer) Writer {
+	return &write{
+		zipWriter:       zip.NewWriter(w),
+		sharedStringIDs: make(map[string]int),
+	}
+}
+
+// NewSheet start a new sheet, the previous sheet will be finished.
+func (w *write) NewSheet(sheetNamer interface{}) error {
+	if w.closed {
+		return ErrWriterClosed
+	}
+	name := inferSheetName(sheetNamer)
+	if err := checkSheetName(name); err != nil {
+		return err
+	}
+	for _, sheet := range w.sheets {
+		if strings.EqualFold(sheet, name) {
+			return fmt.Errorf("duplicated sheet name = %s", name)
+		}
+	}
+	if err := w.finishSheet(); err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("%s%d.xml", _WorkSheetsPrefix, len(w.sheets)+1)
+	part, err := w.zipWriter.Create(path)
+	if err != nil {
+		return err
+	}
+	w.sheets = append(w.sheets, name)
+	w.sheet = &sheetWriter{
+		name:   name,
+		buffer: bufio.NewWriter(part),
+	}
+	w.sheet.buffer.WriteString(_XMLHeader)
+	w.sheet.buffer.WriteString(`<worksheet xmlns="` + _NSMain + `" xmlns:r="` + _NSRelationships + `"><sheetData>`)
+	return nil
+}
+
+// Write a struct as a row.
+func (w *write) Write(v interface{}) error {
+	if w.closed {
+		return ErrWriterClosed
+	}
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return fmt.Errorf("can not write nil %T", v)
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return fmt.Errorf("%T should be struct or pointer to struct", v)
+	}
+	if w.sheet == nil {
+		if err := w.NewSheet(v); err != nil {
+			return err
+		}
+	}
+	if w.sheet.schema == nil {
+		if err := w.writeTitles(newSchema(val.Type())); err != nil {
+			return err
+		}
+	} else if w.sheet.schema.Type != val.Type() {
+		return fmt.Errorf("sheet %s is written by %s, can not write %s", w.sheet.name, w.sheet.schema.Type, val.Type())
+	}
+	return w.writeValue(val)
+}
+
+// WriteAll write all elements of container as rows.
+func (w *write) WriteAll(container interface{}) error {
+	val := reflect.Indirect(reflect.ValueOf(container))
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+	default:
+		return fmt.Errorf("%T should be slice or array of struct", container)
+	}
+	if w.sheet == nil {
+		if err := w.NewSheet(container); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < val.Len(); i++ {
+		if err := w.Write(val.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close finish current sheet and write the workbook parts.
+func (w *write) Close() error {
+	if w.closed {
+		return nil
+	}
+	if len(w.sheets) == 0 {
+		// a workbook must contain at least one sheet.
+		if err := w.NewSheet("Sheet1"); err != nil {
+			return err
+		}
+	}
+	if err := w.finishSheet(); err != nil {
+		return err
+	}
+	w.closed = true
+
+	parts := []struct {
+		path  string
+		write func(*bufio.Writer)
+	}{
+		{_SharedStringPath, w.writeSharedStrings},
+		{_StylesPath, w.writeStyles},
+		{_WorkBookPath, w.writeWorkbook},
+		{_WorkBookRels, w.writeWorkbookRels},
+		{_RootRels, w.writeRootRels},
+		{_ContentTypesPath, w.writeContentTypes},
+	}
+	for _, p := range parts {
+		part, err := w.zipWriter.Create(p.path)
+		if err != nil {
+			return err
+		}
+		buffer := bufio.NewWriter(part)
+		buffer.WriteString(_XMLHeader)
+		p.write(buffer)
+		if err = buffer.Flush(); err != nil {
+			return err
+		}
+	}
+	w.sharedStrings = nil
+	w.sharedStringIDs = nil
+	return w.zipWriter.Close()
+}
+
+func (w *write) finishSheet() error {
+	if w.sheet == nil {
+		return nil
+	}
+	w.sheet.buffer.WriteString(`</sheetData></worksheet>`)
+	err := w.sheet.buffer.Flush()
+	w.sheet = nil
+	return err
+}
+
+func (w *write) writeTitles(s *schema) error {
+	if s.err != nil {
+		return s.err
+	}
+	sheet := w.sheet
+	sheet.schema = s
+	written := make(map[string]bool, len(s.Fields))
+	var fields []*fieldConfig
+	for _, field := range s.Fields {
+		if written[field.ColumnName] {
+			continue
+		}
+		written[field.ColumnName] = true
+		if field.Index == "" {
+			fields = append(fields, field)
+			continue
+		}
+		// place the field with index(n) at column n like reading.
+		for len(sheet.columns) <= field.columnIndex {
+			sheet.columns = append(sheet.columns, nil)
+		}
+		if other := sheet.columns[field.columnIndex]; other != nil {
+			return fmt.Errorf("sheet %s has both %s and %s at column %s", sheet.name, other.FieldName, field.FieldName, ToColumnName(field.columnIndex))
+		}
+		sheet.columns[field.columnIndex] = field
+	}
+	for i := 0; len(fields) > 0; i++ {
+		if i == len(sheet.columns) {
+			sheet.columns = append(sheet.columns, nil)
+		}
+		if sheet.columns[i] == nil {
+			sheet.columns[i], fields = fields[0], fields[1:]
+		}
+	}
+	if len(sheet.columns) > _MaxColumns {
+		return fmt.Errorf("sheet %s has %d columns, more than %d", sheet.name, len(sheet.columns), _MaxColumns)
+	}
+
+	sheet.rowIndex++
+	row := strconv.Itoa(sheet.rowIndex)
+	sheet.buffer.WriteString(`<row r="` + row + `">`)
+	for i, field := range sheet.columns {
+		if field == nil {
+			continue
+		}
+		w.writeCell(ToColumnName(i)+row, _S, strconv.Itoa(w.sharedStringID(field.ColumnName)), "")
+	}
+	sheet.buffer.WriteString(`</row>`)
+	return nil
+}
+
+func (w *write) writeValue(v reflect.Value) error {
+	sheet := w.sheet
+	if sheet.rowIndex >= _MaxRows {
+		return fmt.Errorf("sheet %s has more than %d rows", sheet.name, _MaxRows)
+	}
+	sheet.rowIndex++
+	row := strconv.Itoa(sheet.rowIndex)
+	sheet.buffer.WriteString(`<row r="` + row + `">`)
+	for i, field := range sheet.columns {
+		if field == nil {
+			continue
+		}
+		ref := ToColumnName(i) + row
+		fieldValue, ok := lookupField(v, field.FieldIndex)
+		if !ok && field.DefaultValue == "" {
+			// the nested struct is nil, nothing to write.
+			continue
+		}
+		if !ok || field.DefaultValue != "" && fieldValue.IsZero() {
+			// write the default like reading the empty cell.
+			defaultValue := reflect.New(sheet.schema.Type.FieldByIndex(field.FieldIndex).Type).Elem()
+			if err := field.ScanDefault(defaultValue, timeContext{}); err != nil {
+				return fmt.Errorf("write %s of sheet %s failed: %s", ref, sheet.name, err)
+			}
+			fieldValue = defaultValue
+		}
+		typ, val, style, err := marshalCell(fieldValue, field.Split)
+		if err != nil {
+			return fmt.Errorf("write %s of sheet %s failed: %s", ref, sheet.name, err)
+		}
+		if typ == "" && val == "" {
+			// empty cell, nothing to write.
+			continue
+		}
+		if typ == _S {
+			val = strconv.Itoa(w.sharedStringID(val))
+		}
+		w.writeCell(ref, typ, val, style)
+	}
+	sheet.buffer.WriteString(`</row>`)
+	return nil
+}
+
+func (w *write) writeCell(ref, typ, val, style string) {
+	buffer := w.sheet.buffer
+	buffer.WriteString(`<c r="` + ref + `"`)
+	if typ != "" {
+		buffer.WriteString(` t="` + typ + `"`)
+	}
+	if style != "" {
+		buffer.WriteString(` s="` + style + `"`)
+	}
+	buffer.WriteString(`><v>` + val + `</v></c>`)
+}
+
+func (w *write) sharedStringID(s string) int {
+	w.sharedStringRefs++
+	if id, ok := w.sharedStringIDs[s]; ok {
+		return id
+	}
+	id := len(w.sharedStrings)
+	w.sharedStrings = append(w.sharedStrings, s)
+	w.sharedStringIDs[s] = id
+	return id
+}
+
+func (w *write) writeSharedStrings(buffer *bufio.Writer) {
+	fmt.Fprintf(buffer, `<sst xmlns="%s" count="%d" uniqueCount="%d">`, _NSMain, w.sharedStringRefs, len(w.sharedStrings))
+	for _, s := range w.sharedStrings {
+		if strings.TrimSpace(s) != s {
+			buffer.WriteString(`<si><t xml:space="preserve">`)
+		} else {
+			buffer.WriteString(`<si><t>`)
+		}
+		_ = xml.EscapeText(buffer, []byte(s))
+		buffer.WriteString(`</t></si>`)
+	}
+	buffer.WriteString(`</sst>`)
+}
+
+func (w *write) writeStyles(buffer *bufio.Writer) {
+	// cellXfs[0] is the default style, cellXfs[1] is the style of date time (numFmtId 22 = "m/d/yy h:mm").
+	buffer.WriteString(`<styleSheet xmlns="` + _NSMain + `">` +
+		`<fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="22" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs>` +
+		`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+		`</styleSheet>`)
+}
+
+func (w *write) writeWorkbook(buffer *bufio.Writer) {
+	buffer.WriteString(`<workbook xmlns="` + _NSMain + `" xmlns:r="` + _NSRelationships + `"><sheets>`)
+	for i, name := range w.sheets {
+		buffer.WriteString(`<sheet name="`)
+		_ = xml.EscapeText(buffer, []byte(name))
+		fmt.Fprintf(buffer, `" sheetId="%d" r:id="rId%d"/>`, i+1, i+1)
+	}
+	buffer.WriteString(`</sheets></workbook>`)
+}
+
+func (w *write) writeWorkbookRels(buffer *bufio.Writer) {
+	buffer.WriteString(`<Relationships xmlns="` + _NSPackageRels + `">`)
+	for i := range w.sheets {
+		fmt.Fprintf(buffer, `<Relationship Id="rId%d" Type="%s" Target="worksheets/sheet%d.xml"/>`, i+1, _RelTypeWorkSheet, i+1)
+	}
+	fmt.Fprintf(buffer, `<Relationship Id="rId%d" Type="%s" Target="styles.xml"/>`, len(w.sheets)+1, _RelTypeStyles)
+	fmt.Fprintf(buffer, `<Relationship Id="rId%d" Type="%s" Target="sharedStrings.xml"/>`, len(w.sheets)+2, _RelTypeSharedStrings)
+	buffer.WriteString(`</Relationships>`)
+}
+
+func (w *write) writeRootRels(buffer *bufio.Writer) {
+	buffer.WriteString(`<Relationships xmlns="` + _NSPackageRels + `">`)
+	fmt.Fprintf(buffer, `<Relationship Id="rId1" Type="%s" Target="%s"/>`, _RelTypeOfficeDocument, _WorkBookPath)
+	buffer.WriteString(`</Relationships>`)
+}
+
+func (w *write) writeContentTypes(buffer *bufio.Writer) {
+	const ct = "application/vnd.openxmlformats-officedocument.spreadsheetml."
+	buffer.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/` + _WorkBookPath + `" ContentType="` + ct + `sheet.main+xml"/>` +
+		`<Override PartName="/` + _StylesPath + `" ContentType="` + ct + `styles+xml"/>` +
+		`<Override PartName="/` + _SharedStringPath + `" ContentType="` + ct + `sharedStrings+xml"/>`)
+	for i := range w.sheets {
+		fmt.Fprintf(buffer, `<Override PartName="/%s%d.xml" ContentType="%sworksheet+xml"/>`, _WorkSheetsPrefix, i+1, ct)
+	}
+	buffer.WriteString(`</Types>`)
+}
+
+// marshalCell convert a field value to the type, value and style of a cell.
+// typ and val are both empty if the cell should be left empty.
+func marshalCell(v reflect.Value, split string) (typ, val, style string, err error) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", "", "", nil
+		}
+		if v.Type().Elem() == timeType {
+			return marshalCell(v.Elem(), split)
+		}
+	}
+	if v.CanInterface() {
+		switch i := v.Interface().(type) {
+		case time.Time:
+			if i.IsZero() {
+				return "", "", "", nil
+			}
+			return "", strconv.FormatFloat(timeToExcelTime(i), 'f', -1, 64), _StyleDateTime, nil
+		case encoding.TextMarshaler:
+			d, err := i.MarshalText()
+			return _S, string(d), "", err
+		case encoding.BinaryMarshaler:
+			d, err := i.MarshalBinary()
+			return _S, string(d), "", err
+		}
+		if v.Kind() != reflect.Ptr && v.CanAddr() {
+			if _, ok := v.Addr().Interface().(encoding.TextMarshaler); ok {
+				return marshalCell(v.Addr(), split)
+			}
+			if _, ok := v.Addr().Interface().(encoding.BinaryMarshaler); ok {
+				return marshalCell(v.Addr(), split)
+			}
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return "", "", "", nil
+		}
+		return marshalCell(v.Elem(), split)
+	case reflect.String:
+		return _S, v.String(), "", nil
+	case reflect.Bool:
+		if v.Bool() {
+			return _B, "1", "", nil
+		}
+		return _B, "0", "", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "", strconv.FormatInt(v.Int(), 10), "", nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "", strconv.FormatUint(v.Uint(), 10), "", nil
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", "", "", fmt.Errorf("can't marshal %v into a cell", f)
+		}
+		return "", strconv.FormatFloat(f, 'f', -1, v.Type().Bits()), "", nil
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 && v.Kind() == reflect.Slice {
+			return _S, string(v.Bytes()), "", nil
+		}
+		if len(split) == 0 {
+			return "", "", "", fmt.Errorf("can't marshal %s without split", v.Type())
+		}
+		elems := make([]string, v.Len())
+		for i := range elems {
+			_, elems[i], _, err = marshalCell(v.Index(i), split)
+			if err != nil {
+				return "", "", "", err
+			}
+		}
+		return _S, strings.Join(elems, split), "", nil
+	default:
+		return "", "", "", fmt.Errorf("can't marshal %s (consider implementing encoding.TextMarshaler)", v.Type())
+	}
+}
+
+func checkSheetName(name string) error {
+	if name == "" {
+		return errors.New("sheet name can not be empty")
+	}
+	if len([]rune(name)) > _MaxSheetName {
+		return fmt.Errorf("sheet name = %s is longer than %d", name, _MaxSheetName)
+	}
+	if strings.ContainsAny(name, `[]:*?/\`) {
+		return fmt.Errorf("sheet name = %s contains invalid character", name)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package excel
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type Export struct {
+	ID       int
+	Name     string  `xlsx:"column(NameOf)"`
+	NamePtr  *string `xlsx:"column(NameOf)"`
+	Score    float64
+	Passed   bool
+	Tags     []string `xlsx:"split(|)"`
+	Temp     *Temp    `xlsx:"column(UnmarshalString)"`
+	Birthday time.Time
+	Ignored  string `xlsx:"-"`
+}
+
+var expectExportList = []Export{
+	{
+		ID:       1,
+		Name:     "Andy",
+		NamePtr:  StrPtr("Andy"),
+		Score:    99.5,
+		Passed:   true,
+		Tags:     []string{"a", "b"},
+		Temp:     &Temp{Foo: "Andy"},
+		Birthday: time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC),
+	},
+	{
+		ID:      2,
+		Name:    " Leo <&> ",
+		NamePtr: StrPtr(" Leo <&> "),
+		Tags:    []string{"c"},
+	},
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	data, err := Marshal(expectExportList)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	conn := NewConnector()
+	err = conn.OpenBinary(data)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer conn.Close()
+
+	if names := conn.GetSheetNames(); !reflect.DeepEqual(names, []string{"Export"}) {
+		t.Errorf("unexpect sheet names: %v", names)
+	}
+
+	rd, err := conn.NewReader(expectExportList)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer rd.Close()
+
+	expectTitles := []string{"ID", "NameOf", "Score", "Passed", "Tags", "UnmarshalString", "Birthday"}
+	if titles := rd.GetTitles(); !reflect.DeepEqual(titles, expectTitles) {
+		t.Errorf("unexpect titles: %v", titles)
+	}
+
+	var list []Export
+	err = rd.ReadAll(&list)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(list) != len(expectExportList) {
+		t.Fatalf("unexpect export list: %s", MustJsonPrettyString(list))
+	}
+	for i := range list {
+		expect := expectExportList[i]
+		if !reflect.DeepEqual(list[i], expect) {
+			t.Errorf("unexpect export at %d = \n%s", i, MustJsonPrettyString(list[i]))
+		}
+	}
+}
+
+type IndexedExport struct {
+	Name  string `xlsx:"index(2)"`
+	ID    int
+	Level int `xlsx:"default(3)"`
+}
+
+func TestMarshalIndexAndDefault(t *testing.T) {
+	data, err := Marshal([]IndexedExport{{Name: "Andy", ID: 1}, {Name: "Leo", ID: 2, Level: 5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn := NewConnector()
+	if err = conn.OpenBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// the field with index is placed at its column, the zero field is written as default.
+	var rows [][]string
+	if err = conn.MustReader("IndexedExport").ReadAll(&rows); err != nil {
+		t.Fatal(err)
+	}
+	if expect := [][]string{{"1", "3", "Andy"}, {"2", "5", "Leo"}}; !reflect.DeepEqual(rows, expect) {
+		t.Errorf("unexpect rows: %v", rows)
+	}
+	var list []IndexedExport
+	if err = conn.MustReader("IndexedExport").ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	if expect := []IndexedExport{{Name: "Andy", ID: 1, Level: 3}, {Name: "Leo", ID: 2, Level: 5}}; !reflect.DeepEqual(list, expect) {
+		t.Errorf("unexpect list: %v", list)
+	}
+}
+
+func TestWriterMultiSheet(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	w := NewWriter(buffer)
+	if err := w.NewSheet("First"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteAll(expectExportList); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.NewSheet("first"); err == nil {
+		t.Error("expect error of duplicated sheet name")
+	}
+	if err := w.NewSheet("Second"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(&expectStandardList[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(expectExportList[0]); err == nil {
+		t.Error("expect error of writing another type into a sheet")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(expectExportList[0]); err != ErrWriterClosed {
+		t.Errorf("expect ErrWriterClosed but got: %+v", err)
+	}
+
+	conn := NewConnector()
+	if err := conn.OpenBinary(buffer.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	rd, err := conn.NewReader("Second")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	var list []map[string]string
+	if err = rd.ReadAll(&list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0]["NameOf"] != "Andy" || list[0]["Slice"] != "1|2" {
+		t.Errorf("unexpect map list: %s", MustJsonPrettyString(list))
+	}
+}
+
+func TestToColumnName(t *testing.T) {
+	tests := []struct {
+		index int
+		want  string
+	}{
+		{0, "A"},
+		{25, "Z"},
+		{26, "AA"},
+		{701, "ZZ"},
+		{702, "AAA"},
+		{16383, "XFD"},
+	}
+	for _, tt := range tests {
+		if got := ToColumnName(tt.index); got != tt.want {
+			t.Errorf("ToColumnName(%d) = %s, want %s", tt.index, got, tt.want)
+		}
+		if got := ToDecimalism(tt.want); got != tt.index {
+			t.Errorf("ToDecimalism(%s) = %d, want %d", tt.want, got, tt.index)
+		}
+	}
+}
@@ -0,0 +1,614 @@
+package excel
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf16"
+)
+
+// BIFF8 record types
+const (
+	_BIFFBOF         = 0x0809
+	_BIFFEOF         = 0x000A
+	_BIFFContinue    = 0x003C
+	_BIFFFilePass    = 0x002F
+	_BIFFBoundSheet  = 0x0085
+	_BIFFDateMode    = 0x0022
+	_BIFFFormat      = 0x041E
+	_BIFFXF          = 0x00E0
+	_BIFFSST         = 0x00FC
+	_BIFFLabelSST    = 0x00FD
+	_BIFFLabel       = 0x0204
+	_BIFFNumber      = 0x0203
+	_BIFFRK          = 0x027E
+	_BIFFMulRK       = 0x00BD
+	_BIFFBoolErr     = 0x0205
+	_BIFFFormula     = 0x0006
+	_BIFFString      = 0x0207
+	_BIFFMergedCells = 0x00E5
+	_BIFFDimensions  = 0x0200
+
+	// BIFF8的版本号
+	_BIFF8Version = 0x0600
+	// BOF类型：工作表
+	_BIFFWorksheet = 0x0010
+)
+
+var (
+	// ErrXLSNotSupported means the xls is not BIFF8 (Excel 97-2003) or is encrypted.
+	ErrXLSNotSupported = errors.New("only unencrypted BIFF8 (Excel 97-2003) xls is supported")
+
+	// error values of BoolErr and Formula records
+	biffErrors = map[byte]string{
+		0x00: "#NULL!",
+		0x07: "#DIV/0!",
+		0x0F: "#VALUE!",
+		0x17: "#REF!",
+		0x1D: "#NAME?",
+		0x24: "#NUM!",
+		0x2A: "#N/A",
+	}
+)
+
+// biffRecord is a record of BIFF8 stream, the data of CONTINUE records are kept as the following segments.
+type biffRecord struct {
+	typ      uint16
+	segments [][]byte
+}
+
+func (r *biffRecord) data() []byte {
+	return r.segments[0]
+}
+
+// xlsBook is a workbook of xls (BIFF8).
+type xlsBook struct {
+	stream []byte
+	sheets []xlsSheetInfo
+	// shared strings
+	sst []string
+	// number format of every XF
+	xfFormats []uint16
+	// map[ifmt]format code
+	formats  map[uint16]string
+	date1904 bool
+}
+
+type xlsSheetInfo struct {
+	name string
+	// offset of BOF of sheet in stream
+	offset uint32
+	// the hidden state of BoundSheet, 0 visible, 1 hidden and 2 very hidden
+	state byte
+}
+
+// openXLS read the workbook stream of Compound File Binary.
+func openXLS(cfb *cfbFile) (*xlsBook, error) {
+	stream, err := cfb.stream("Workbook")
+	if err != nil {
+		if _, e := cfb.stream("Book"); e == nil {
+			// BIFF5 and before
+			return nil, ErrXLSNotSupported
+		}
+		return nil, err
+	}
+	book := &xlsBook{
+		stream:  stream,
+		formats: make(map[uint16]string),
+	}
+	if err = book.readGlobals(); err != nil {
+		return nil, fmt.Errorf("read xls workbook failed: %w", err)
+	}
+	return book, nil
+}
+
+// readRecord read the record at offset with its CONTINUE records, return the offset of next record.
+func (book *xlsBook) readRecord(offset int) (*biffRecord, int, error) {
+	readHeader := func(offset int) (typ uint16, data []byte, next int, err error) {
+		if offset+4 > len(book.stream) {
+			return 0, nil, 0, fmt.Errorf("record at %d is truncated", offset)
+		}
+		typ = binary.LittleEndian.Uint16(book.stream[offset:])
+		size := int(binary.LittleEndian.Uint16(book.stream[offset+2:]))
+		next = offset + 4 + size
+		if next > len(book.stream) {
+			return 0, nil, 0, fmt.Errorf("record 0x%04X at %d is truncated", typ, offset)
+		}
+		return typ, book.stream[offset+4 : next], next, nil
+	}
+	typ, data, next, err := readHeader(offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	record := &biffRecord{typ: typ, segments: [][]byte{data}}
+	for next+4 <= len(book.stream) && binary.LittleEndian.Uint16(book.stream[next:]) == _BIFFContinue {
+		_, data, next, err = readHeader(next)
+		if err != nil {
+			return nil, 0, err
+		}
+		record.segments = append(record.segments, data)
+	}
+	return record, next, nil
+}
+
+func (book *xlsBook) readGlobals() error {
+	record, offset, err := book.readRecord(0)
+	if err != nil {
+		return err
+	}
+	if record.typ != _BIFFBOF || len(record.data()) < 2 || binary.LittleEndian.Uint16(record.data()) != _BIFF8Version {
+		return ErrXLSNotSupported
+	}
+	for offset < len(book.stream) {
+		if record, offset, err = book.readRecord(offset); err != nil {
+			return err
+		}
+		data := record.data()
+		switch record.typ {
+		case _BIFFEOF:
+			return nil
+		case _BIFFFilePass:
+			return ErrXLSNotSupported
+		case _BIFFBoundSheet:
+			if len(data) < 8 {
+				return fmt.Errorf("bad BoundSheet record")
+			}
+			if data[5] != 0 {
+				// chart, macro or VBA module
+				continue
+			}
+			name, _, err := readBIFFString(data[6:], int(data[6]), 1)
+			if err != nil {
+				return err
+			}
+			book.sheets = append(book.sheets, xlsSheetInfo{name: name, offset: binary.LittleEndian.Uint32(data), state: data[4] & 0x03})
+		case _BIFFDateMode:
+			book.date1904 = len(data) >= 2 && binary.LittleEndian.Uint16(data) == 1
+		case _BIFFFormat:
+			if len(data) < 4 {
+				return fmt.Errorf("bad Format record")
+			}
+			code, _, err := readBIFFString(data[2:], int(binary.LittleEndian.Uint16(data[2:])), 2)
+			if err != nil {
+				return err
+			}
+			book.formats[binary.LittleEndian.Uint16(data)] = code
+		case _BIFFXF:
+			if len(data) < 4 {
+				return fmt.Errorf("bad XF record")
+			}
+			book.xfFormats = append(book.xfFormats, binary.LittleEndian.Uint16(data[2:]))
+		case _BIFFSST:
+			if book.sst, err = readSST(record.segments); err != nil {
+				return fmt.Errorf("read shared strings failed: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// readBIFFString read a XLUnicodeString of cch chars from data, the length field takes lenSize bytes,
+// return the string and the bytes read.
+func readBIFFString(data []byte, cch int, lenSize int) (string, int, error) {
+	if len(data) < lenSize+1 {
+		return "", 0, fmt.Errorf("string is truncated")
+	}
+	flags := data[lenSize]
+	n := lenSize + 1
+	charSize := 1
+	if flags&0x01 != 0 {
+		charSize = 2
+	}
+	if len(data) < n+cch*charSize {
+		return "", 0, fmt.Errorf("string is truncated")
+	}
+	s := decodeBIFFChars(data[n:n+cch*charSize], charSize)
+	return s, n + cch*charSize, nil
+}
+
+// decodeBIFFChars decode the compressed (latin1) or UTF-16 chars.
+func decodeBIFFChars(data []byte, charSize int) string {
+	if charSize == 1 {
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		return string(runes)
+	}
+	chars := make([]uint16, len(data)/2)
+	for i := range chars {
+		chars[i] = binary.LittleEndian.Uint16(data[i*2:])
+	}
+	return string(utf16.Decode(chars))
+}
+
+// segmentReader read across the segments of record and its CONTINUE records.
+type segmentReader struct {
+	segments [][]byte
+	segment  int
+	pos      int
+}
+
+func (r *segmentReader) available() int {
+	for r.segment < len(r.segments) && r.pos >= len(r.segments[r.segment]) {
+		r.segment++
+		r.pos = 0
+	}
+	if r.segment >= len(r.segments) {
+		return 0
+	}
+	return len(r.segments[r.segment]) - r.pos
+}
+
+// read n bytes, may cross segments.
+func (r *segmentReader) read(n int) ([]byte, error) {
+	var data []byte
+	for n > 0 {
+		available := r.available()
+		if available == 0 {
+			return nil, fmt.Errorf("unexpect end of record")
+		}
+		if available > n {
+			available = n
+		}
+		data = append(data, r.segments[r.segment][r.pos:r.pos+available]...)
+		r.pos += available
+		n -= available
+	}
+	return data, nil
+}
+
+// readChars read cch chars started with flags, a new flags byte starts every CONTINUE segment.
+func (r *segmentReader) readChars(cch int, flags byte) (string, error) {
+	var chars []rune
+	for cch > 0 {
+		if r.pos >= len(r.segments[r.segment]) {
+			// the chars are continued in next segment with a new flags byte.
+			if r.available() == 0 {
+				return "", fmt.Errorf("unexpect end of record")
+			}
+			flags = r.segments[r.segment][r.pos]
+			r.pos++
+		}
+		charSize := 1
+		if flags&0x01 != 0 {
+			charSize = 2
+		}
+		n := (len(r.segments[r.segment]) - r.pos) / charSize
+		if n > cch {
+			n = cch
+		}
+		if n == 0 {
+			return "", fmt.Errorf("unexpect end of record")
+		}
+		chars = append(chars, []rune(decodeBIFFChars(r.segments[r.segment][r.pos:r.pos+n*charSize], charSize))...)
+		r.pos += n * charSize
+		cch -= n
+	}
+	return string(chars), nil
+}
+
+// readSST read the XLUnicodeRichExtendedString of SST record.
+func readSST(segments [][]byte) ([]string, error) {
+	r := &segmentReader{segments: segments}
+	header, err := r.read(8)
+	if err != nil {
+		return nil, err
+	}
+	count := int(binary.LittleEndian.Uint32(header[4:]))
+	// every string takes 3 bytes at least, the count from file is not trusted.
+	size := 0
+	for _, segment := range segments {
+		size += len(segment)
+	}
+	sst := make([]string, 0, min(count, size/3))
+	for i := 0; i < count; i++ {
+		b, err := r.read(3)
+		if err != nil {
+			return nil, err
+		}
+		cch := int(binary.LittleEndian.Uint16(b))
+		flags := b[2]
+		runs, extSize := 0, 0
+		if flags&0x08 != 0 {
+			if b, err = r.read(2); err != nil {
+				return nil, err
+			}
+			runs = int(binary.LittleEndian.Uint16(b))
+		}
+		if flags&0x04 != 0 {
+			if b, err = r.read(4); err != nil {
+				return nil, err
+			}
+			extSize = int(int32(binary.LittleEndian.Uint32(b)))
+		}
+		s, err := r.readChars(cch, flags)
+		if err != nil {
+			return nil, err
+		}
+		// skip the formatting runs and phonetic data
+		if _, err = r.read(runs*4 + extSize); err != nil {
+			return nil, err
+		}
+		sst = append(sst, s)
+	}
+	return sst, nil
+}
+
+func (book *xlsBook) sheetNames() []string {
+	names := make([]string, len(book.sheets))
+	for i, sheet := range book.sheets {
+		names[i] = sheet.name
+	}
+	return names
+}
+
+func (book *xlsBook) sheetNameOf(i int) string {
+	if i < 1 || i > len(book.sheets) {
+		return ""
+	}
+	return book.sheets[i-1].name
+}
+
+// sheetInfos return the metadata of sheets, the dimension is read from the Dimensions record of sheet.
+func (book *xlsBook) sheetInfos() ([]SheetInfo, error) {
+	infos := make([]SheetInfo, len(book.sheets))
+	for i, sheet := range book.sheets {
+		dimension, err := book.readDimension(sheet)
+		if err != nil {
+			return nil, fmt.Errorf("read xls sheet %s failed: %w", sheet.name, err)
+		}
+		infos[i] = SheetInfo{
+			Name:       sheet.name,
+			Index:      i + 1,
+			Visibility: SheetVisibility(sheet.state),
+			Dimension:  dimension,
+			Rows:       dimensionRows(dimension),
+		}
+	}
+	return infos, nil
+}
+
+// readDimension read the Dimensions record of sheet, it's before the cells, empty if the sheet is empty.
+func (book *xlsBook) readDimension(info xlsSheetInfo) (string, error) {
+	for offset := int(info.offset); offset < len(book.stream); {
+		record, next, err := book.readRecord(offset)
+		if err != nil {
+			return "", err
+		}
+		offset = next
+		switch record.typ {
+		case _BIFFEOF:
+			return "", nil
+		case _BIFFDimensions:
+			data := record.data()
+			if len(data) < 12 {
+				return "", fmt.Errorf("bad Dimensions record")
+			}
+			// the last row and column are exclusive
+			firstRow, lastRow := binary.LittleEndian.Uint32(data), binary.LittleEndian.Uint32(data[4:])
+			firstColumn, lastColumn := binary.LittleEndian.Uint16(data[8:]), binary.LittleEndian.Uint16(data[10:])
+			if lastRow <= firstRow || lastColumn <= firstColumn {
+				return "", nil
+			}
+			return ToColumnName(int(firstColumn)) + strconv.Itoa(int(firstRow)+1) + ":" +
+				ToColumnName(int(lastColumn)-1) + strconv.Itoa(int(lastRow)), nil
+		}
+	}
+	return "", nil
+}
+
+// is1904 report whether the DATEMODE record is set.
+func (book *xlsBook) is1904() bool {
+	return book.date1904
+}
+
+// definedNames return nil since the Name records of xls are not parsed.
+func (book *xlsBook) definedNames() []DefinedName {
+	return nil
+}
+
+func (book *xlsBook) close() error {
+	book.stream = nil
+	book.sst = nil
+	return nil
+}
+
+func (book *xlsBook) openSheet(sheet string, config *Config) (rowSource, []*mergeCell, error) {
+	for _, info := range book.sheets {
+		if info.name == sheet {
+			location := config.Location
+			if location == nil {
+				location = time.UTC
+			}
+			rows, merges, err := book.readSheet(info, location)
+			if err != nil {
+				return nil, nil, fmt.Errorf("read xls sheet %s failed: %w", sheet, err)
+			}
+			return rows, merges, nil
+		}
+	}
+	return nil, nil, ErrSheetNotExist
+}
+
+// readSheet load the cells of sheet into memory.
+func (book *xlsBook) readSheet(info xlsSheetInfo, location *time.Location) (*memoryRows, []*mergeCell, error) {
+	offset := int(info.offset)
+	record, offset, err := book.readRecord(offset)
+	if err != nil {
+		return nil, nil, err
+	}
+	if record.typ != _BIFFBOF || len(record.data()) < 4 || binary.LittleEndian.Uint16(record.data()[2:]) != _BIFFWorksheet {
+		return nil, nil, fmt.Errorf("bad BOF of sheet")
+	}
+	start := offset
+	// map[row]cells
+	rows := make(map[int][]*rowCell)
+	var merges []*mergeCell
+	addCell := func(row, column int, cell Cell) {
+		cell.Ref = ToColumnName(column) + strconv.Itoa(row+1)
+		rows[row] = append(rows[row], &rowCell{Cell: cell, columnIndex: column})
+	}
+	// add the cell at the row and column of record
+	addRecordCell := func(data []byte, cell Cell) {
+		row, column := biffRowColumn(data)
+		addCell(row, column, cell)
+	}
+	// the formula result of string is in the next String record.
+	formulaRow, formulaColumn := -1, -1
+	for offset < len(book.stream) {
+		if record, offset, err = book.readRecord(offset); err != nil {
+			return nil, nil, err
+		}
+		data := record.data()
+		if record.typ == _BIFFEOF {
+			break
+		}
+		if record.typ != _BIFFString && record.typ != _BIFFMergedCells && len(data) < 6 {
+			continue
+		}
+		switch record.typ {
+		case _BIFFLabelSST:
+			if len(data) < 10 {
+				return nil, nil, fmt.Errorf("bad LabelSST record")
+			}
+			id := int(binary.LittleEndian.Uint32(data[6:]))
+			if id >= len(book.sst) {
+				return nil, nil, fmt.Errorf("shared string id = %d out of range [0,%d)", id, len(book.sst))
+			}
+			addRecordCell(data, Cell{Type: CellTypeString, Value: book.sst[id]})
+		case _BIFFLabel:
+			if len(data) < 8 {
+				return nil, nil, fmt.Errorf("bad Label record")
+			}
+			s, _, err := readBIFFString(data[6:], int(binary.LittleEndian.Uint16(data[6:])), 2)
+			if err != nil {
+				return nil, nil, err
+			}
+			addRecordCell(data, Cell{Type: CellTypeString, Value: s})
+		case _BIFFNumber:
+			if len(data) < 14 {
+				return nil, nil, fmt.Errorf("bad Number record")
+			}
+			f := math.Float64frombits(binary.LittleEndian.Uint64(data[6:]))
+			addRecordCell(data, book.numberCell(f, binary.LittleEndian.Uint16(data[4:]), location))
+		case _BIFFRK:
+			if len(data) < 10 {
+				return nil, nil, fmt.Errorf("bad RK record")
+			}
+			f := decodeRK(binary.LittleEndian.Uint32(data[6:]))
+			addRecordCell(data, book.numberCell(f, binary.LittleEndian.Uint16(data[4:]), location))
+		case _BIFFMulRK:
+			row := int(binary.LittleEndian.Uint16(data))
+			column := int(binary.LittleEndian.Uint16(data[2:]))
+			for i := 4; i+6 <= len(data)-2; i += 6 {
+				f := decodeRK(binary.LittleEndian.Uint32(data[i+2:]))
+				addCell(row, column, book.numberCell(f, binary.LittleEndian.Uint16(data[i:]), location))
+				column++
+			}
+		case _BIFFBoolErr:
+			if len(data) < 8 {
+				return nil, nil, fmt.Errorf("bad BoolErr record")
+			}
+			addRecordCell(data, boolErrCell(data[6], data[7] == 1))
+		case _BIFFFormula:
+			if len(data) < 14 {
+				return nil, nil, fmt.Errorf("bad Formula record")
+			}
+			row, column := biffRowColumn(data)
+			result := data[6:14]
+			if result[6] != 0xFF || result[7] != 0xFF {
+				f := math.Float64frombits(binary.LittleEndian.Uint64(result))
+				addCell(row, column, book.numberCell(f, binary.LittleEndian.Uint16(data[4:]), location))
+				break
+			}
+			switch result[0] {
+			case 0:
+				formulaRow, formulaColumn = row, column
+			case 1:
+				addCell(row, column, boolErrCell(result[2], false))
+			case 2:
+				addCell(row, column, boolErrCell(result[2], true))
+			}
+		case _BIFFString:
+			if formulaRow < 0 || len(data) < 3 {
+				break
+			}
+			s, _, err := readBIFFString(data, int(binary.LittleEndian.Uint16(data)), 2)
+			if err != nil {
+				return nil, nil, err
+			}
+			addCell(formulaRow, formulaColumn, Cell{Type: CellTypeString, Value: s})
+			formulaRow, formulaColumn = -1, -1
+		case _BIFFMergedCells:
+			for i := 2; i+8 <= len(data); i += 8 {
+				merges = append(merges, &mergeCell{
+					firstRow:    int(binary.LittleEndian.Uint16(data[i:])) + 1,
+					lastRow:     int(binary.LittleEndian.Uint16(data[i+2:])) + 1,
+					firstColumn: int(binary.LittleEndian.Uint16(data[i+4:])),
+					lastColumn:  int(binary.LittleEndian.Uint16(data[i+6:])),
+				})
+			}
+		}
+	}
+
+	mr := &memoryRows{rows: make([]*memoryRow, 0, len(rows)), size: int64(offset - start)}
+	for row, cells := range rows {
+		sort.SliceStable(cells, func(i, j int) bool {
+			return cells[i].columnIndex < cells[j].columnIndex
+		})
+		mr.rows = append(mr.rows, &memoryRow{number: row + 1, cells: cells})
+	}
+	sort.Slice(mr.rows, func(i, j int) bool {
+		return mr.rows[i].number < mr.rows[j].number
+	})
+	return mr, merges, nil
+}
+
+// numberCell make a number cell, it's a date if formatted by xf as date.
+func (book *xlsBook) numberCell(f float64, xf uint16, location *time.Location) Cell {
+	if int(xf) < len(book.xfFormats) {
+		ifmt := book.xfFormats[xf]
+		code, ok := book.formats[ifmt]
+		if (ok && isDateFormatCode(code)) || (!ok && isBuiltInDateFormat(int(ifmt))) {
+			t := excelTimeToTime(f, book.date1904, location)
+			return Cell{Type: CellTypeDate, Value: t.Format(time.RFC3339Nano)}
+		}
+	}
+	return Cell{Type: CellTypeNumber, Value: strconv.FormatFloat(f, 'f', -1, 64)}
+}
+
+func boolErrCell(value byte, isError bool) Cell {
+	if !isError {
+		if value != 0 {
+			return Cell{Type: CellTypeBool, Value: "1"}
+		}
+		return Cell{Type: CellTypeBool, Value: "0"}
+	}
+	if s, ok := biffErrors[value]; ok {
+		return Cell{Type: CellTypeError, Value: s}
+	}
+	return Cell{Type: CellTypeError, Value: fmt.Sprintf("#ERR%d", value)}
+}
+
+func biffRowColumn(data []byte) (row, column int) {
+	return int(binary.LittleEndian.Uint16(data)), int(binary.LittleEndian.Uint16(data[2:]))
+}
+
+// decodeRK decode the RK number, it's a float64 with the low 34 bits dropped or a int30, may be multiplied by 100.
+func decodeRK(rk uint32) float64 {
+	var f float64
+	if rk&0x02 != 0 {
+		f = float64(int32(rk) >> 2)
+	} else {
+		f = math.Float64frombits(uint64(rk&0xFFFFFFFC) << 32)
+	}
+	if rk&0x01 != 0 {
+		f /= 100
+	}
+	return f
+}
@@ -0,0 +1,271 @@
+package excel
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+	"unicode/utf16"
+)
+
+// biffWriter write the records of BIFF8 stream.
+type biffWriter struct {
+	bytes.Buffer
+}
+
+func (w *biffWriter) record(typ uint16, data ...[]byte) {
+	for i, segment := range data {
+		if i > 0 {
+			typ = _BIFFContinue
+		}
+		w.Write(binary.LittleEndian.AppendUint16(nil, typ))
+		w.Write(binary.LittleEndian.AppendUint16(nil, uint16(len(segment))))
+		w.Write(segment)
+	}
+}
+
+func biffBytes(values ...interface{}) []byte {
+	var b []byte
+	for _, v := range values {
+		switch v := v.(type) {
+		case uint16:
+			b = binary.LittleEndian.AppendUint16(b, v)
+		case uint32:
+			b = binary.LittleEndian.AppendUint32(b, v)
+		case float64:
+			b = binary.LittleEndian.AppendUint64(b, math.Float64bits(v))
+		case byte:
+			b = append(b, v)
+		case []byte:
+			b = append(b, v...)
+		case string:
+			// compressed chars
+			b = append(b, v...)
+		case []uint16:
+			for _, c := range v {
+				b = binary.LittleEndian.AppendUint16(b, c)
+			}
+		}
+	}
+	return b
+}
+
+// testXLSStream build the workbook stream with sheet "XLSRow".
+func testXLSStream() []byte {
+	sheet := &biffWriter{}
+	sheet.record(_BIFFBOF, biffBytes(uint16(_BIFF8Version), uint16(_BIFFWorksheet), uint16(0), uint16(0), uint32(0), uint32(0)))
+	sheet.record(_BIFFDimensions, biffBytes(uint32(0), uint32(5), uint16(0), uint16(6), uint16(0)))
+	// title: ID, Name, Score, Birthday, Active, Note
+	for i := uint16(0); i < 6; i++ {
+		sheet.record(_BIFFLabelSST, biffBytes(uint16(0), i, uint16(0), uint32(i)))
+	}
+	// row 2: RK of int, SST of unicode, Number, date of custom format, bool, formula of string
+	sheet.record(_BIFFRK, biffBytes(uint16(1), uint16(0), uint16(0), uint32(1<<2|0x02)))
+	sheet.record(_BIFFLabelSST, biffBytes(uint16(1), uint16(1), uint16(0), uint32(6)))
+	sheet.record(_BIFFNumber, biffBytes(uint16(1), uint16(2), uint16(0), 98.5))
+	sheet.record(_BIFFNumber, biffBytes(uint16(1), uint16(3), uint16(1), 36526.5))
+	sheet.record(_BIFFBoolErr, biffBytes(uint16(1), uint16(4), uint16(0), byte(1), byte(0)))
+	sheet.record(_BIFFFormula, biffBytes(uint16(1), uint16(5), uint16(0), byte(0), byte(0), byte(0), byte(0), byte(0), byte(0), uint16(0xFFFF), uint16(0), uint32(0), uint16(0)))
+	sheet.record(_BIFFString, biffBytes(uint16(4), byte(0), "text"))
+	// row 3: RK, Label, MulRK of int*100 and date of built-in format, error
+	sheet.record(_BIFFRK, biffBytes(uint16(2), uint16(0), uint16(0), uint32(2<<2|0x02)))
+	sheet.record(_BIFFLabel, biffBytes(uint16(2), uint16(1), uint16(0), uint16(3), byte(0), "Leo"))
+	sheet.record(_BIFFMulRK, biffBytes(uint16(2), uint16(2), uint16(0), uint32(9925<<2|0x03), uint16(2), uint32(36527<<2|0x02), uint16(3)))
+	sheet.record(_BIFFBoolErr, biffBytes(uint16(2), uint16(5), uint16(0), byte(0x07), byte(1)))
+	// row 4 is merged to row 5
+	sheet.record(_BIFFRK, biffBytes(uint16(3), uint16(0), uint16(0), uint32(3<<2|0x02)))
+	sheet.record(_BIFFLabelSST, biffBytes(uint16(3), uint16(5), uint16(0), uint32(7)))
+	sheet.record(_BIFFRK, biffBytes(uint16(4), uint16(0), uint16(0), uint32(4<<2|0x02)))
+	sheet.record(_BIFFMergedCells, biffBytes(uint16(1), uint16(3), uint16(4), uint16(5), uint16(5)))
+	sheet.record(_BIFFEOF, nil)
+
+	globals := func(sheetOffset uint32) []byte {
+		w := &biffWriter{}
+		w.record(_BIFFBOF, biffBytes(uint16(_BIFF8Version), uint16(0x0005), uint16(0), uint16(0), uint32(0), uint32(0)))
+		w.record(_BIFFFormat, biffBytes(uint16(164), uint16(10), byte(0), "yyyy-mm-dd"))
+		// xf 0: General, xf 1: custom date format, xf 2: built-in date format
+		for _, ifmt := range []uint16{0, 164, 14} {
+			w.record(_BIFFXF, biffBytes(uint16(0), ifmt, make([]byte, 16)))
+		}
+		w.record(_BIFFBoundSheet, biffBytes(sheetOffset, byte(1), byte(0), byte(6), byte(0), "XLSRow"))
+		// the unicode string "张三" is split by CONTINUE, and continued with compressed flags.
+		name := append(biffBytes(uint16(4), byte(1)), biffBytes([]uint16{'张', '三'})...)
+		w.record(_BIFFSST,
+			biffBytes(uint32(8), uint32(8),
+				uint16(2), byte(0), "ID", uint16(4), byte(0), "Name", uint16(5), byte(0), "Score",
+				uint16(8), byte(0), "Birthday", uint16(6), byte(0), "Active", uint16(4), byte(0), "Note",
+				name),
+			biffBytes(byte(0), "AB",
+				// rich text with 1 formatting run
+				uint16(6), byte(0x08), uint16(1), "merged", uint32(0)))
+		w.record(_BIFFEOF, nil)
+		return w.Bytes()
+	}
+	stream := globals(0)
+	stream = append(globals(uint32(len(stream))), sheet.Bytes()...)
+	// make it larger than the mini stream cutoff
+	return append(stream, make([]byte, 4096)...)
+}
+
+// testCFB wrap the stream named name into a Compound File Binary of version 3.
+// sector 0 is FAT, sector 1 is directory and the stream starts from sector 2.
+func testCFB(name string, stream []byte) []byte {
+	const sectorSize = 512
+	le := binary.LittleEndian
+	header := make([]byte, sectorSize)
+	copy(header, cfbSignature)
+	le.PutUint16(header[24:], 0x3E)
+	le.PutUint16(header[26:], 3)
+	le.PutUint16(header[28:], 0xFFFE)
+	le.PutUint16(header[30:], 9)
+	le.PutUint16(header[32:], 6)
+	le.PutUint32(header[44:], 1)
+	le.PutUint32(header[48:], 1)
+	le.PutUint32(header[56:], 4096)
+	le.PutUint32(header[60:], _CFBEndOfChain)
+	le.PutUint32(header[68:], _CFBEndOfChain)
+	for i := 0; i < 109; i++ {
+		le.PutUint32(header[76+i*4:], _CFBFreeSect)
+	}
+	le.PutUint32(header[76:], 0)
+
+	sectors := (len(stream) + sectorSize - 1) / sectorSize
+	fat := make([]byte, sectorSize)
+	for i := 0; i < sectorSize/4; i++ {
+		next := uint32(_CFBFreeSect)
+		switch {
+		case i == 0:
+			next = _CFBFATSect
+		case i == 1 || i == sectors+1:
+			next = _CFBEndOfChain
+		case i < sectors+1:
+			next = uint32(i + 1)
+		}
+		le.PutUint32(fat[i*4:], next)
+	}
+
+	dir := make([]byte, sectorSize)
+	entry := func(i int, name string, kind byte, child, start uint32, size uint64) {
+		data := dir[i*_CFBDirEntrySize:]
+		chars := utf16.Encode([]rune(name))
+		for j, c := range chars {
+			le.PutUint16(data[j*2:], c)
+		}
+		le.PutUint16(data[64:], uint16(len(chars)*2+2))
+		data[66] = kind
+		le.PutUint32(data[68:], _CFBFreeSect)
+		le.PutUint32(data[72:], _CFBFreeSect)
+		le.PutUint32(data[76:], child)
+		le.PutUint32(data[116:], start)
+		le.PutUint64(data[120:], size)
+	}
+	entry(0, "Root Entry", _CFBRoot, 1, _CFBEndOfChain, 0)
+	entry(1, name, _CFBStream, _CFBFreeSect, 2, uint64(len(stream)))
+
+	data := append(append(header, fat...), dir...)
+	data = append(data, stream...)
+	return append(data, make([]byte, sectors*sectorSize-len(stream))...)
+}
+
+type XLSRow struct {
+	ID       int
+	Name     string
+	Score    float64
+	Birthday time.Time
+	Active   bool
+	Note     string
+}
+
+func TestReadXLS(t *testing.T) {
+	conn := NewConnector()
+	if err := conn.OpenBinary(testCFB("Workbook", testXLSStream())); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if names := conn.GetSheetNames(); !reflect.DeepEqual(names, []string{"XLSRow"}) {
+		t.Errorf("unexpect sheet names: %v", names)
+	}
+	infos, err := conn.GetSheetInfos()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := []SheetInfo{{Name: "XLSRow", Index: 1, Visibility: SheetHidden, Dimension: "A1:F5", Rows: 5}}; !reflect.DeepEqual(infos, expect) {
+		t.Errorf("unexpect sheet infos: %+v", infos)
+	}
+
+	rd, err := conn.NewReaderByConfig(&Config{Sheet: 1, FillMergedCells: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	var cells []map[string]Cell
+	if err = rd.ReadAll(&cells); err != nil {
+		t.Fatal(err)
+	}
+	list := make([]map[string]string, len(cells))
+	for i, row := range cells {
+		list[i] = make(map[string]string)
+		for title, cell := range row {
+			list[i][title] = cell.Value
+		}
+	}
+	if c := cells[1]["Note"]; c.Type != CellTypeError || c.Ref != "F3" {
+		t.Errorf("unexpect error cell: %+v", c)
+	}
+	expectList := []map[string]string{
+		{"ID": "1", "Name": "张三AB", "Score": "98.5", "Birthday": "2000-01-01T12:00:00Z", "Active": "1", "Note": "text"},
+		{"ID": "2", "Name": "Leo", "Score": "99.25", "Birthday": "2000-01-02T00:00:00Z", "Active": "", "Note": "#DIV/0!"},
+		{"ID": "3", "Name": "", "Score": "", "Birthday": "", "Active": "", "Note": "merged"},
+		{"ID": "4", "Name": "", "Score": "", "Birthday": "", "Active": "", "Note": "merged"},
+	}
+	if !reflect.DeepEqual(list, expectList) {
+		t.Errorf("unexpect list: \n%s", MustJsonPrettyString(list))
+	}
+
+	var rows []XLSRow
+	rd, err = conn.NewReaderByConfig(&Config{Sheet: "XLSRow", Location: time.FixedZone("CST", 8*3600)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	for rd.Next() {
+		var row XLSRow
+		if err := rd.Read(&row); err != nil {
+			// the error cell
+			if _, ok := err.(*CellError); !ok {
+				t.Fatal(err)
+			}
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) != 4 || rows[0].Name != "张三AB" || !rows[0].Active || rows[1].Score != 99.25 ||
+		rows[0].Birthday.Format(time.RFC3339) != "2000-01-01T12:00:00+08:00" {
+		t.Errorf("unexpect rows: \n%s", MustJsonPrettyString(rows))
+	}
+}
+
+func TestOpenXLSNotSupported(t *testing.T) {
+	conn := NewConnector()
+	if err := conn.OpenBinary(testCFB("Book", testXLSStream())); err != ErrXLSNotSupported {
+		t.Errorf("expect ErrXLSNotSupported but got: %v", err)
+	}
+}
+
+func TestOpenXLSBadCount(t *testing.T) {
+	// the counts from file are limited by the size of file.
+	data := testCFB("Workbook", testXLSStream())
+	binary.LittleEndian.PutUint32(data[44:], 0xFFFFFFFF)
+	if _, err := openCFB(bytes.NewReader(data), int64(len(data))); !errors.Is(err, ErrInvalidCFB) {
+		t.Errorf("expect ErrInvalidCFB but got: %v", err)
+	}
+
+	sst := make([]byte, 8)
+	binary.LittleEndian.PutUint32(sst[4:], 0xFFFFFFFF)
+	if _, err := readSST([][]byte{sst}); err == nil {
+		t.Error("expect error of bad count of SST")
+	}
+}
@@ -0,0 +1,270 @@
+package excel
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+)
+
+// xlsxRows is the rows of a worksheet file of xlsx.
+type xlsxRows struct {
+	connecter          *connect
+	decoder            *xml.Decoder
+	decoderReadCloseer io.ReadCloser
+	// location of date cells
+	location *time.Location
+	// map["si"]*sharedFormula
+	sharedFormulas map[string]*sharedFormula
+	// number of current row, starts from 1
+	row int
+	// index of previous cell in current row, -1 at the start of row
+	column int
+	// uncompressed size of the worksheet file
+	size int64
+}
+
+func (sh *xlsxRows) next() bool {
+	var tempCell *xlsxC
+	for t, err := sh.decoder.Token(); err == nil; t, err = sh.decoder.Token() {
+		switch token := t.(type) {
+		case xml.StartElement:
+			switch token.Name.Local {
+			case _RowPrefix:
+				sh.startRow(&token)
+				return true
+			case _C:
+				// the cell of row not read, the master of shared formula is kept for the cells after.
+				if tempCell == nil {
+					tempCell = &xlsxC{}
+				}
+				if err := sh.decodeCell(&token, tempCell); err == nil && tempCell.F != nil && tempCell.F.Ref != "" {
+					_, _ = sh.readFormula(tempCell)
+				}
+			}
+		}
+	}
+	return false
+}
+
+// startRow record the number of row started by token.
+func (sh *xlsxRows) startRow(token *xml.StartElement) {
+	sh.column = -1
+	for _, a := range token.Attr {
+		if a.Name.Local == _R {
+			if n, err := strconv.Atoi(a.Value); err == nil {
+				sh.row = n
+				return
+			}
+		}
+	}
+	// the r attribute is optional, the row follows the previous one.
+	sh.row++
+}
+
+func (sh *xlsxRows) readRow() ([]*rowCell, error) {
+	tempCell := &xlsxC{}
+	var cells []*rowCell
+	for t, e := sh.decoder.Token(); e == nil; t, e = sh.decoder.Token() {
+		switch token := t.(type) {
+		case xml.StartElement:
+			switch token.Name.Local {
+			case _RowPrefix:
+				sh.startRow(&token)
+			case _C:
+				if err := sh.decodeCell(&token, tempCell); err != nil {
+					return nil, err
+				}
+				if tempCell.isEmpty() {
+					break
+				}
+				cell, err := sh.readCell(tempCell)
+				cells = append(cells, &rowCell{
+					Cell:        cell,
+					columnIndex: tempCell.columnIndex,
+					err:         err,
+				})
+			}
+		case xml.EndElement:
+			if token.Name.Local == _RowPrefix {
+				// end of current row
+				return cells, nil
+			}
+		}
+	}
+	return nil, io.EOF
+}
+
+// decodeCell decode the whole c element started by token into cell.
+func (sh *xlsxRows) decodeCell(token *xml.StartElement, cell *xlsxC) error {
+	*cell = xlsxC{}
+	if err := sh.decoder.DecodeElement(cell, token); err != nil {
+		return err
+	}
+	if cell.R == "" {
+		// the r attribute is optional, the cell follows the previous one.
+		cell.R = ToColumnName(sh.column+1) + strconv.Itoa(sh.row)
+	}
+	columnIndex, _, err := parseCellRef(cell.R)
+	if err != nil {
+		return err
+	}
+	cell.columnIndex = columnIndex
+	sh.column = columnIndex
+	return nil
+}
+
+// readCell resolve the value, type and formula of cell.
+func (sh *xlsxRows) readCell(c *xlsxC) (cell Cell, err error) {
+	cell.Ref = c.R
+	cell.Value = c.V
+	switch c.T {
+	case _S:
+		// get string from shared
+		tokenInt, err := ToInt(c.V)
+		if err != nil {
+			return cell, err
+		}
+		cell.Type = CellTypeString
+		cell.Value, err = sh.connecter.getSharedString(tokenInt)
+		if err != nil {
+			return cell, err
+		}
+	case _InlineStr:
+		cell.Type = CellTypeString
+		if c.IS != nil {
+			cell.Value = c.IS.String()
+		}
+	case _Str:
+		cell.Type = CellTypeString
+	case _B:
+		cell.Type = CellTypeBool
+	case _E:
+		cell.Type = CellTypeError
+	case _D:
+		cell.Type = CellTypeDate
+		if t, ok := parseISODate(c.V, sh.location); ok {
+			cell.Value = t.Format(time.RFC3339Nano)
+		}
+	case "", _N:
+		cell.Type = CellTypeNumber
+		if sh.connecter.isDateStyle(c.S) {
+			if f, err := strconv.ParseFloat(c.V, 64); err == nil {
+				t := excelTimeToTime(f, sh.connecter.date1904, sh.location)
+				cell.Type = CellTypeDate
+				cell.Value = t.Format(time.RFC3339Nano)
+			}
+		}
+	}
+	if c.F != nil {
+		cell.Formula, err = sh.readFormula(c)
+	}
+	return cell, err
+}
+
+// the layouts of ISO 8601 date cell, the zone is optional.
+var isoDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02",
+	"15:04:05.999999999",
+}
+
+// parseISODate parse the value of date cell (t="d"), the one without zone is in location.
+func parseISODate(value string, location *time.Location) (time.Time, bool) {
+	for _, layout := range isoDateLayouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// readFormula get the formula of c, the shared formula will be translated.
+func (sh *xlsxRows) readFormula(c *xlsxC) (string, error) {
+	f := c.F
+	if f.T != _SharedFormula {
+		return f.Text, nil
+	}
+	column, row, err := parseCellRef(c.R)
+	if err != nil {
+		return "", err
+	}
+	if f.Ref != "" {
+		// master of shared formula
+		if sh.sharedFormulas == nil {
+			sh.sharedFormulas = make(map[string]*sharedFormula)
+		}
+		sh.sharedFormulas[f.SI] = &sharedFormula{formula: f.Text, row: row, column: column}
+		return f.Text, nil
+	}
+	master, ok := sh.sharedFormulas[f.SI]
+	if !ok {
+		// the master is lost, such as in a bad file, the cached value is still usable.
+		return "", nil
+	}
+	return shiftFormula(master.formula, row-master.row, column-master.column), nil
+}
+
+// newXlsxRows make the rows of worksheet file, the cursor is moved into sheetData.
+func newXlsxRows(cn *connect, rc io.ReadCloser, location *time.Location) (*xlsxRows, error) {
+	decoder := xml.NewDecoder(rc)
+	// step into root [xml.StartElement] token
+	func(decoder *xml.Decoder) {
+		for t, err := decoder.Token(); err == nil; t, err = decoder.Token() {
+			// [xml.ProcInst]
+			// [xml.CharData]
+			// [xml.StartElement]
+			switch t.(type) {
+			case xml.StartElement:
+				return
+			}
+		}
+	}(decoder)
+
+	err := func(decoder *xml.Decoder) error {
+		// use func block to break to 'for' range
+		for t, err := decoder.Token(); err == nil; t, err = decoder.Token() {
+			// log.Printf("%+v\n\n", t)
+			switch token := t.(type) {
+			case xml.StartElement:
+				switch token.Name.Local {
+				case _SheetData:
+					return nil
+				default:
+					if err := decoder.Skip(); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	}(decoder)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sh := &xlsxRows{
+		connecter:          cn,
+		decoder:            decoder,
+		decoderReadCloseer: rc,
+		location:           location,
+	}
+
+	return sh, nil
+}
+
+func (sh *xlsxRows) rowNumber() int {
+	return sh.row
+}
+
+func (sh *xlsxRows) progress() (consumed, total int64) {
+	return sh.decoder.InputOffset(), sh.size
+}
+
+func (sh *xlsxRows) close() error {
+	sh.sharedFormulas = nil
+	return sh.decoderReadCloseer.Close()
+}
@@ -1,13 +1,59 @@
 package excel
 
+import "strings"
+
 // xlsxC directly maps the c element in the namespace
 // http://schemas.openxmlformats.org/spreadsheetml/2006/main -
 // currently I have not checked it for completeness - it does as much
 // as I need.
 type xlsxC struct {
-	R string `xml:"r,attr"`           // Cell ID, e.g. A1
-	T string `xml:"t,attr,omitempty"` // Type.
-	V string `xml:"v,omitempty"`      // Value
+	R  string  `xml:"r,attr"`           // Cell ID, e.g. A1
+	S  string  `xml:"s,attr,omitempty"` // Style, the index of cellXfs in xl/styles.xml
+	T  string  `xml:"t,attr,omitempty"` // Type.
+	F  *xlsxF  `xml:"f"`                // Formula
+	V  string  `xml:"v,omitempty"`      // Value
+	IS *xlsxSI `xml:"is"`               // Inline string, used when T is "inlineStr"
 
 	columnIndex int // cache the columnIndex
 }
+
+// xlsxF directly maps the f element, the formula of cell.
+// A shared formula is only written in the master cell (with ref),
+// the other cells refer to it by si.
+type xlsxF struct {
+	Text string `xml:",chardata"`
+	T    string `xml:"t,attr,omitempty"`   // Type, e.g. shared
+	Ref  string `xml:"ref,attr,omitempty"` // Range of cells shared the formula
+	SI   string `xml:"si,attr,omitempty"`  // Index of shared formula
+}
+
+// xlsxSI directly maps the si element of sharedStrings.xml and
+// the is element of inline string, the text is either a plain
+// t element or rich text runs.
+type xlsxSI struct {
+	T string  `xml:"t"`
+	R []xlsxR `xml:"r"`
+}
+
+// xlsxR directly maps the r element, a run of rich text.
+type xlsxR struct {
+	T string `xml:"t"`
+}
+
+// String concatenate the plain text and all runs.
+func (si *xlsxSI) String() string {
+	if len(si.R) == 0 {
+		return si.T
+	}
+	var b strings.Builder
+	b.WriteString(si.T)
+	for _, r := range si.R {
+		b.WriteString(r.T)
+	}
+	return b.String()
+}
+
+// isEmpty is true if there is nothing in the cell, such as <c r="A1" s="1"/>.
+func (c *xlsxC) isEmpty() bool {
+	return c.V == "" && c.IS == nil && c.F == nil
+}
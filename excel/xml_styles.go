@@ -0,0 +1,106 @@
+package excel
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+func readStyleSheetXML(rd io.Reader) (*xlsxStyleSheet, error) {
+	var err error
+	styleSheet := new(xlsxStyleSheet)
+	decoder := xml.NewDecoder(rd)
+	err = decoder.Decode(styleSheet)
+	if err != nil {
+		return nil, err
+	}
+	return styleSheet, nil
+}
+
+// xlsxStyleSheet directly maps the styleSheet element in the namespace
+// http://schemas.openxmlformats.org/spreadsheetml/2006/main -
+// only the number formats are mapped.
+type xlsxStyleSheet struct {
+	NumFmts xlsxNumFmts `xml:"numFmts"`
+	CellXfs xlsxCellXfs `xml:"cellXfs"`
+}
+
+// xlsxNumFmts directly maps the numFmts element, the custom number formats.
+type xlsxNumFmts struct {
+	NumFmt []xlsxNumFmt `xml:"numFmt"`
+}
+
+// xlsxNumFmt directly maps the numFmt element.
+type xlsxNumFmt struct {
+	NumFmtID   int    `xml:"numFmtId,attr"`
+	FormatCode string `xml:"formatCode,attr"`
+}
+
+// xlsxCellXfs directly maps the cellXfs element, the s attribute of c is the index of xf.
+type xlsxCellXfs struct {
+	Xf []xlsxXf `xml:"xf"`
+}
+
+// xlsxXf directly maps the xf element.
+type xlsxXf struct {
+	NumFmtID int `xml:"numFmtId,attr"`
+}
+
+// dateStyles return whether each xf of cellXfs is formatted as date or time.
+func (ss *xlsxStyleSheet) dateStyles() []bool {
+	customFormats := make(map[int]string, len(ss.NumFmts.NumFmt))
+	for _, numFmt := range ss.NumFmts.NumFmt {
+		customFormats[numFmt.NumFmtID] = numFmt.FormatCode
+	}
+	styles := make([]bool, len(ss.CellXfs.Xf))
+	for i, xf := range ss.CellXfs.Xf {
+		if code, ok := customFormats[xf.NumFmtID]; ok {
+			styles[i] = isDateFormatCode(code)
+		} else {
+			styles[i] = isBuiltInDateFormat(xf.NumFmtID)
+		}
+	}
+	return styles
+}
+
+// isBuiltInDateFormat report whether the built-in numFmtId is date or time,
+// 27~36 and 50~58 are the date formats of CJK locales.
+func isBuiltInDateFormat(id int) bool {
+	switch {
+	case id >= 14 && id <= 22,
+		id >= 27 && id <= 36,
+		id >= 45 && id <= 47,
+		id >= 50 && id <= 58:
+		return true
+	}
+	return false
+}
+
+// isDateFormatCode report whether the custom format code is date or time,
+// the text in quotes, escaped char and the [...] sections like color and locale are ignored.
+func isDateFormatCode(code string) bool {
+	// only the first section (for positive number) decides the type
+	inQuote, inBracket, escaped := false, false, false
+	for _, c := range code {
+		switch {
+		case escaped:
+			escaped = false
+		case inQuote:
+			inQuote = c != '"'
+		case inBracket:
+			inBracket = c != ']'
+		case c == '\\' || c == '_' || c == '*':
+			// the next char is literal or padding
+			escaped = true
+		case c == '"':
+			inQuote = true
+		case c == '[':
+			inBracket = true
+		case c == ';':
+			return false
+		case strings.ContainsRune("yYmMdDhHsS", c):
+			return true
+		}
+	}
+	return false
+}
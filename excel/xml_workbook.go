@@ -44,7 +44,16 @@ type xlsxWorkbookRelation struct {
 // xlsxWorkbook directly maps the workbook element from the namespace
 // http://schemas.openxmlformats.org/spreadsheetml/2006/main
 type xlsxWorkbook struct {
-	Sheets xlsxSheets `xml:"sheets"`
+	WorkbookPr   xlsxWorkbookPr   `xml:"workbookPr"`
+	Sheets       xlsxSheets       `xml:"sheets"`
+	DefinedNames xlsxDefinedNames `xml:"definedNames"`
+}
+
+// xlsxWorkbookPr directly maps the workbookPr element from the namespace
+// http://schemas.openxmlformats.org/spreadsheetml/2006/main
+type xlsxWorkbookPr struct {
+	// use 1904 date system if true
+	Date1904 bool `xml:"date1904,attr,omitempty"`
 }
 
 // xlsxSheets directly maps the sheets element from the namespace
@@ -59,4 +68,22 @@ type xlsxSheet struct {
 	Name    string `xml:"name,attr,omitempty"`
 	SheetID string `xml:"sheetId,attr,omitempty"`
 	RID     string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr,omitempty"`
+	// "hidden", "veryHidden" or empty for visible
+	State string `xml:"state,attr,omitempty"`
+}
+
+// xlsxDefinedNames directly maps the definedNames element from the namespace
+// http://schemas.openxmlformats.org/spreadsheetml/2006/main
+type xlsxDefinedNames struct {
+	DefinedName []xlsxDefinedName `xml:"definedName"`
+}
+
+// xlsxDefinedName directly maps the definedName element from the namespace
+// http://schemas.openxmlformats.org/spreadsheetml/2006/main
+type xlsxDefinedName struct {
+	Name string `xml:"name,attr"`
+	// index of the sheet in workbook (starts from 0) if the name is local to it
+	LocalSheetID *int   `xml:"localSheetId,attr"`
+	Hidden       bool   `xml:"hidden,attr,omitempty"`
+	Value        string `xml:",chardata"`
 }
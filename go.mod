@@ -1,9 +1,15 @@
 module github.com/zhao520a1a/go-utils
 
-go 1.16
+go 1.23
 
 require (
 	github.com/cheekybits/genny v1.0.1-0.20200709201058-3e22f1a88ff2
-	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/stretchr/testify v1.7.1-0.20210824115523-ab6dc3262822
+	golang.org/x/text v0.21.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
 )